@@ -0,0 +1,230 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+)
+
+// DefaultMaxSteps bounds how many model turns one Run may take.
+const DefaultMaxSteps = 16
+
+// Agent drives the tool-use loop.
+type Agent struct {
+	Client *claude.Client
+	Tools  []Tool
+	Policy *Policy
+	// Confirm is consulted for Approve-classified tool calls; nil denies
+	// them. The input is rendered as JSON for display.
+	Confirm func(tool, input string) bool
+	// MaxSteps bounds model turns; 0 applies DefaultMaxSteps.
+	MaxSteps int
+	// Verbose logs each tool call and its outcome to stderr.
+	Verbose bool
+	// TokenEfficient enables the token-efficient-tools beta, which encodes
+	// tool declarations and calls more compactly. Per-turn usage is logged
+	// in Verbose mode so runs with and without it can be compared.
+	TokenEfficient bool
+	// OnEvent receives tool lifecycle events (see ToolEvent) as they
+	// happen; nil disables them.
+	OnEvent func(ToolEvent)
+	// Trace, when non-nil, records every model turn and tool call as
+	// timed spans for later export (see Trace).
+	Trace *Trace
+}
+
+// TokenEfficientToolsBeta is the anthropic_beta flag for compact tool
+// encoding.
+const TokenEfficientToolsBeta = "token-efficient-tools-2025-02-19"
+
+func (a *Agent) tool(name string) Tool {
+	for _, t := range a.Tools {
+		if t.Name() == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// Run answers the prompt, executing tool calls as the model requests them,
+// and returns the final text plus accumulated usage.
+func (a *Agent) Run(ctx context.Context, system, prompt string, maxTokens int32) (string, claude.Usage, error) {
+	req := claude.Request{
+		System:    system,
+		Messages:  []claude.Message{claude.UserMessage(prompt)},
+		MaxTokens: maxTokens,
+	}
+	for _, t := range a.Tools {
+		if decl, ok := t.(AnthropicDeclared); ok {
+			req.AnthropicTools = append(req.AnthropicTools, decl.Declaration())
+			for _, beta := range decl.Betas() {
+				if !contains(req.Betas, beta) {
+					req.Betas = append(req.Betas, beta)
+				}
+			}
+			continue
+		}
+		req.Tools = append(req.Tools, Spec(t))
+	}
+	if a.TokenEfficient && !contains(req.Betas, TokenEfficientToolsBeta) {
+		req.Betas = append(req.Betas, TokenEfficientToolsBeta)
+	}
+	policy := a.Policy
+	if policy == nil {
+		policy = DefaultPolicy()
+	}
+	maxSteps := a.MaxSteps
+	if maxSteps <= 0 {
+		maxSteps = DefaultMaxSteps
+	}
+
+	var total claude.Usage
+	for step := 0; step < maxSteps; step++ {
+		span := a.Trace.begin("model", a.Client.ModelID, "", 0)
+		msg, stopReason, usage, err := a.Client.ConverseTurn(ctx, req)
+		if err != nil {
+			a.Trace.end(span, "", nil, err)
+			return "", total, err
+		}
+		a.Trace.end(span, textOf(msg), &usage, nil)
+		total.Add(usage)
+		if a.Verbose {
+			log.Printf("agent: step %d: %d in, %d out (total %d in, %d out)",
+				step+1, usage.InputTokens, usage.OutputTokens, total.InputTokens, total.OutputTokens)
+		}
+		req.Messages = append(req.Messages, *msg)
+
+		if stopReason != types.StopReasonToolUse {
+			return textOf(msg), total, nil
+		}
+
+		var results []types.ContentBlock
+		for _, block := range msg.Content {
+			use, ok := block.(*types.ContentBlockMemberToolUse)
+			if !ok {
+				continue
+			}
+			results = append(results, a.execute(ctx, policy, use.Value, span))
+		}
+		if len(results) == 0 {
+			return "", total, fmt.Errorf("agent: tool_use stop without tool calls")
+		}
+		req.Messages = append(req.Messages, claude.Message{
+			Role:    types.ConversationRoleUser,
+			Content: results,
+		})
+	}
+	return "", total, fmt.Errorf("agent: no final answer after %d steps", maxSteps)
+}
+
+// execute runs one tool call under the policy and packages the outcome as a
+// tool result block. Failures and denials are reported back to the model
+// rather than aborting the loop.
+func (a *Agent) execute(ctx context.Context, policy *Policy, use types.ToolUseBlock, parent int) types.ContentBlock {
+	name := ""
+	if use.Name != nil {
+		name = *use.Name
+	}
+	var input map[string]any
+	if use.Input != nil {
+		if err := use.Input.UnmarshalSmithyDocument(&input); err != nil {
+			return toolResult(use.ToolUseId, fmt.Sprintf("invalid tool input: %v", err), true)
+		}
+	}
+	display, _ := json.Marshal(input)
+	id := ""
+	if use.ToolUseId != nil {
+		id = *use.ToolUseId
+	}
+	a.emit(ToolEvent{Kind: ToolCallStarted, ID: id, Tool: name, Input: string(display)})
+	span := a.Trace.begin("tool", name, string(display), parent)
+
+	switch policy.DecideFor(name, a.tool(name)) {
+	case Deny:
+		if a.Verbose {
+			log.Printf("agent: denied %s(%s) by policy", name, display)
+		}
+		err := fmt.Errorf("tool %s is denied by policy", name)
+		a.emit(ToolEvent{Kind: ToolResultReady, ID: id, Tool: name, Err: err})
+		a.Trace.end(span, "", nil, err)
+		return toolResult(use.ToolUseId, err.Error(), true)
+	case Approve:
+		if a.Confirm == nil || !a.Confirm(name, string(display)) {
+			if a.Verbose {
+				log.Printf("agent: %s(%s) not approved", name, display)
+			}
+			err := fmt.Errorf("tool %s was not approved by the user", name)
+			a.emit(ToolEvent{Kind: ToolResultReady, ID: id, Tool: name, Err: err})
+			a.Trace.end(span, "", nil, err)
+			return toolResult(use.ToolUseId, err.Error(), true)
+		}
+	}
+
+	tool := a.tool(name)
+	if tool == nil {
+		err := fmt.Errorf("unknown tool %s", name)
+		a.emit(ToolEvent{Kind: ToolResultReady, ID: id, Tool: name, Err: err})
+		a.Trace.end(span, "", nil, err)
+		return toolResult(use.ToolUseId, err.Error(), true)
+	}
+	if a.Verbose {
+		log.Printf("agent: running %s(%s)", name, display)
+	}
+	a.emit(ToolEvent{Kind: ToolExecuting, ID: id, Tool: name, Input: string(display)})
+	if blockTool, ok := tool.(BlockTool); ok {
+		blocks, err := blockTool.InvokeBlocks(ctx, input)
+		a.emit(ToolEvent{Kind: ToolResultReady, ID: id, Tool: name, Err: err})
+		a.Trace.end(span, "", nil, err)
+		if err != nil {
+			return toolResult(use.ToolUseId, err.Error(), true)
+		}
+		return &types.ContentBlockMemberToolResult{Value: types.ToolResultBlock{
+			ToolUseId: use.ToolUseId,
+			Content:   blocks,
+		}}
+	}
+	out, err := tool.Invoke(ctx, input)
+	a.emit(ToolEvent{Kind: ToolResultReady, ID: id, Tool: name, Output: out, Err: err})
+	a.Trace.end(span, out, nil, err)
+	if err != nil {
+		return toolResult(use.ToolUseId, err.Error(), true)
+	}
+	return toolResult(use.ToolUseId, out, false)
+}
+
+func contains(list []string, want string) bool {
+	for _, v := range list {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
+
+func toolResult(id *string, text string, isErr bool) types.ContentBlock {
+	block := types.ToolResultBlock{
+		ToolUseId: id,
+		Content: []types.ToolResultContentBlock{
+			&types.ToolResultContentBlockMemberText{Value: text},
+		},
+	}
+	if isErr {
+		block.Status = types.ToolResultStatusError
+	}
+	return &types.ContentBlockMemberToolResult{Value: block}
+}
+
+func textOf(msg *claude.Message) string {
+	out := ""
+	for _, block := range msg.Content {
+		if text, ok := block.(*types.ContentBlockMemberText); ok {
+			out += text.Value
+		}
+	}
+	return out
+}
@@ -0,0 +1,115 @@
+//go:build browser
+
+package agent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	"github.com/chromedp/chromedp"
+)
+
+// Browser is a research-oriented tool: unlike full computer use it works on
+// the page structure (navigate, extract text, click elements by CSS
+// selector) rather than pixels, which cheaper models handle reliably.
+type Browser struct {
+	ctx    context.Context
+	cancel []context.CancelFunc
+}
+
+// NewBrowser launches a headless Chrome tab for the tool to drive; Close
+// releases it.
+func NewBrowser(ctx context.Context) *Browser {
+	allocCtx, cancelAlloc := chromedp.NewExecAllocator(ctx, chromedp.DefaultExecAllocatorOptions[:]...)
+	tabCtx, cancelTab := chromedp.NewContext(allocCtx)
+	return &Browser{
+		ctx:    tabCtx,
+		cancel: []context.CancelFunc{cancelTab, cancelAlloc},
+	}
+}
+
+// Close shuts the browser down.
+func (b *Browser) Close() {
+	for _, cancel := range b.cancel {
+		cancel()
+	}
+}
+
+func (b *Browser) Name() string { return "browser" }
+func (b *Browser) Description() string {
+	return "Browse the web: navigate to a URL, extract the visible text of the current page, click an element by CSS selector, or take a screenshot."
+}
+
+func (b *Browser) InputSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"action": map[string]any{
+				"type": "string",
+				"enum": []string{"navigate", "extract", "click", "screenshot"},
+			},
+			"url":      map[string]any{"type": "string", "description": "target for navigate"},
+			"selector": map[string]any{"type": "string", "description": "CSS selector for click"},
+		},
+		"required": []string{"action"},
+	}
+}
+
+// Invoke handles the text-producing actions; screenshot goes through
+// InvokeBlocks.
+func (b *Browser) Invoke(ctx context.Context, input map[string]any) (string, error) {
+	return "", fmt.Errorf("browser: use InvokeBlocks")
+}
+
+// InvokeBlocks executes one browser action.
+func (b *Browser) InvokeBlocks(ctx context.Context, input map[string]any) ([]types.ToolResultContentBlock, error) {
+	action, _ := input["action"].(string)
+	switch action {
+	case "navigate":
+		url, _ := input["url"].(string)
+		if url == "" {
+			return nil, fmt.Errorf("browser: url required for navigate")
+		}
+		var title string
+		if err := chromedp.Run(b.ctx, chromedp.Navigate(url), chromedp.Title(&title)); err != nil {
+			return nil, fmt.Errorf("browser: navigate: %w", err)
+		}
+		return textBlock(fmt.Sprintf("navigated to %s (title: %s)", url, title)), nil
+	case "extract":
+		var text string
+		if err := chromedp.Run(b.ctx, chromedp.Text("body", &text, chromedp.ByQuery)); err != nil {
+			return nil, fmt.Errorf("browser: extract: %w", err)
+		}
+		// Bound what flows back into the context window.
+		const maxExtract = 20000
+		if len(text) > maxExtract {
+			text = text[:maxExtract] + "\n[truncated]"
+		}
+		return textBlock(text), nil
+	case "click":
+		selector, _ := input["selector"].(string)
+		if selector == "" {
+			return nil, fmt.Errorf("browser: selector required for click")
+		}
+		if err := chromedp.Run(b.ctx, chromedp.Click(selector, chromedp.ByQuery)); err != nil {
+			return nil, fmt.Errorf("browser: click %s: %w", selector, err)
+		}
+		return textBlock("clicked " + selector), nil
+	case "screenshot":
+		var png []byte
+		if err := chromedp.Run(b.ctx, chromedp.CaptureScreenshot(&png)); err != nil {
+			return nil, fmt.Errorf("browser: screenshot: %w", err)
+		}
+		return []types.ToolResultContentBlock{
+			&types.ToolResultContentBlockMemberImage{
+				Value: types.ImageBlock{
+					Format: types.ImageFormatPng,
+					Source: &types.ImageSourceMemberBytes{Value: png},
+				},
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("browser: unsupported action %q", action)
+	}
+}
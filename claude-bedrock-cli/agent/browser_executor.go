@@ -0,0 +1,92 @@
+//go:build browser
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/chromedp/cdproto/input"
+	"github.com/chromedp/chromedp"
+)
+
+// BrowserExecutor drives a local headless Chrome tab as the computer-use
+// screen. It is the reference ComputerExecutor; it covers the actions the
+// computer tool emits without needing a desktop environment.
+type BrowserExecutor struct {
+	ctx    context.Context
+	cancel []context.CancelFunc
+}
+
+// NewBrowserExecutor launches headless Chrome at the given viewport size and
+// navigates to startURL. Close releases the browser.
+func NewBrowserExecutor(ctx context.Context, startURL string, width, height int) (*BrowserExecutor, error) {
+	allocCtx, cancelAlloc := chromedp.NewExecAllocator(ctx,
+		append(chromedp.DefaultExecAllocatorOptions[:],
+			chromedp.WindowSize(width, height),
+		)...)
+	tabCtx, cancelTab := chromedp.NewContext(allocCtx)
+	e := &BrowserExecutor{
+		ctx:    tabCtx,
+		cancel: []context.CancelFunc{cancelTab, cancelAlloc},
+	}
+	if err := chromedp.Run(tabCtx, chromedp.Navigate(startURL)); err != nil {
+		e.Close()
+		return nil, fmt.Errorf("browser: opening %s: %w", startURL, err)
+	}
+	return e, nil
+}
+
+// Close shuts the browser down.
+func (e *BrowserExecutor) Close() {
+	for _, cancel := range e.cancel {
+		cancel()
+	}
+}
+
+// Screenshot implements ComputerExecutor.
+func (e *BrowserExecutor) Screenshot(ctx context.Context) ([]byte, error) {
+	var png []byte
+	if err := chromedp.Run(e.ctx, chromedp.CaptureScreenshot(&png)); err != nil {
+		return nil, fmt.Errorf("browser: screenshot: %w", err)
+	}
+	return png, nil
+}
+
+// MouseMove implements ComputerExecutor.
+func (e *BrowserExecutor) MouseMove(ctx context.Context, x, y int) error {
+	return chromedp.Run(e.ctx,
+		input.DispatchMouseEvent(input.MouseMoved, float64(x), float64(y)))
+}
+
+// Click implements ComputerExecutor.
+func (e *BrowserExecutor) Click(ctx context.Context, x, y int) error {
+	return chromedp.Run(e.ctx, chromedp.MouseClickXY(float64(x), float64(y)))
+}
+
+// Type implements ComputerExecutor.
+func (e *BrowserExecutor) Type(ctx context.Context, text string) error {
+	return chromedp.Run(e.ctx, input.InsertText(text))
+}
+
+// namedKeys maps the key names the model uses to the runes chromedp sends.
+var namedKeys = map[string]string{
+	"Return":    "\r",
+	"Enter":     "\r",
+	"Tab":       "\t",
+	"BackSpace": "\b",
+	"space":     " ",
+}
+
+// Key implements ComputerExecutor. Chords (e.g. ctrl+a) are not supported by
+// this executor.
+func (e *BrowserExecutor) Key(ctx context.Context, key string) error {
+	if strings.Contains(key, "+") {
+		return fmt.Errorf("browser: key chords are not supported (%s)", key)
+	}
+	if mapped, ok := namedKeys[key]; ok {
+		key = mapped
+	}
+	return chromedp.Run(e.ctx, chromedp.KeyEvent(key))
+}
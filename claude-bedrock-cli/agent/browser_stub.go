@@ -0,0 +1,74 @@
+//go:build !browser
+
+package agent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+)
+
+// The browser tool and the chromedp-backed computer-use executor pull in
+// headless Chrome, which most users of a samples CLI neither have nor want.
+// The default build ships these stubs; rebuild with -tags browser for the
+// real implementations in browser.go and browser_executor.go.
+
+var errNoBrowser = fmt.Errorf("browser support not compiled in (rebuild with -tags browser)")
+
+// Browser is the stub for the headless-Chrome research tool.
+type Browser struct{}
+
+// NewBrowser returns the stub; every action reports that browser support is
+// not compiled in.
+func NewBrowser(ctx context.Context) *Browser { return &Browser{} }
+
+// Close is a no-op on the stub.
+func (b *Browser) Close() {}
+
+func (b *Browser) Name() string { return "browser" }
+func (b *Browser) Description() string {
+	return "Browse the web: navigate to a URL, extract the visible text of the current page, click an element by CSS selector, or take a screenshot."
+}
+
+func (b *Browser) InputSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"action": map[string]any{
+				"type": "string",
+				"enum": []string{"navigate", "extract", "click", "screenshot"},
+			},
+			"url":      map[string]any{"type": "string", "description": "target for navigate"},
+			"selector": map[string]any{"type": "string", "description": "CSS selector for click"},
+		},
+		"required": []string{"action"},
+	}
+}
+
+func (b *Browser) Invoke(ctx context.Context, input map[string]any) (string, error) {
+	return "", errNoBrowser
+}
+
+func (b *Browser) InvokeBlocks(ctx context.Context, input map[string]any) ([]types.ToolResultContentBlock, error) {
+	return nil, errNoBrowser
+}
+
+// BrowserExecutor is the stub for the headless-Chrome computer-use screen.
+type BrowserExecutor struct{}
+
+// NewBrowserExecutor fails immediately in builds without browser support, so
+// computer-use sessions report the missing tag up front instead of on the
+// first action.
+func NewBrowserExecutor(ctx context.Context, startURL string, width, height int) (*BrowserExecutor, error) {
+	return nil, errNoBrowser
+}
+
+// Close is a no-op on the stub.
+func (e *BrowserExecutor) Close() {}
+
+func (e *BrowserExecutor) Screenshot(ctx context.Context) ([]byte, error) { return nil, errNoBrowser }
+func (e *BrowserExecutor) MouseMove(ctx context.Context, x, y int) error  { return errNoBrowser }
+func (e *BrowserExecutor) Click(ctx context.Context, x, y int) error      { return errNoBrowser }
+func (e *BrowserExecutor) Type(ctx context.Context, text string) error    { return errNoBrowser }
+func (e *BrowserExecutor) Key(ctx context.Context, key string) error      { return errNoBrowser }
@@ -0,0 +1,143 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+)
+
+// ComputerUseBeta is the anthropic_beta flag enabling the computer-use tool
+// types.
+const ComputerUseBeta = "computer-use-2024-10-22"
+
+// ComputerExecutor is the environment a computer-use session drives. The
+// chromedp-backed BrowserExecutor is the reference implementation; desktop
+// or VM executors plug in the same way.
+type ComputerExecutor interface {
+	// Screenshot returns the current screen as PNG.
+	Screenshot(ctx context.Context) ([]byte, error)
+	// MouseMove moves the cursor to pixel coordinates.
+	MouseMove(ctx context.Context, x, y int) error
+	// Click presses and releases the left button at pixel coordinates.
+	Click(ctx context.Context, x, y int) error
+	// Type enters literal text at the current focus.
+	Type(ctx context.Context, text string) error
+	// Key presses a named key or chord, e.g. "Return" or "ctrl+a".
+	Key(ctx context.Context, key string) error
+}
+
+// ComputerTool exposes an executor as Anthropic's computer_20241022 tool.
+type ComputerTool struct {
+	Exec   ComputerExecutor
+	Width  int
+	Height int
+}
+
+func (t ComputerTool) Name() string { return "computer" }
+func (t ComputerTool) Description() string {
+	return "Control a screen with screenshots, mouse, and keyboard."
+}
+
+// InputSchema is unused: the tool is declared through Declaration. It is
+// present to satisfy the Tool interface.
+func (t ComputerTool) InputSchema() map[string]any { return nil }
+
+// Declaration is the Anthropic-defined tool entry passed through
+// additionalModelRequestFields.
+func (t ComputerTool) Declaration() map[string]any {
+	return map[string]any{
+		"type":              "computer_20241022",
+		"name":              "computer",
+		"display_width_px":  t.Width,
+		"display_height_px": t.Height,
+	}
+}
+
+// Betas lists the beta flags the declaration requires.
+func (t ComputerTool) Betas() []string { return []string{ComputerUseBeta} }
+
+// Invoke satisfies Tool; computer use always goes through InvokeBlocks so
+// screenshots can be returned as image blocks.
+func (t ComputerTool) Invoke(ctx context.Context, input map[string]any) (string, error) {
+	return "", fmt.Errorf("computer: use InvokeBlocks")
+}
+
+// InvokeBlocks executes one computer action. Screenshot actions return an
+// image block; everything else acknowledges with text.
+func (t ComputerTool) InvokeBlocks(ctx context.Context, input map[string]any) ([]types.ToolResultContentBlock, error) {
+	action, _ := input["action"].(string)
+	switch action {
+	case "screenshot":
+		png, err := t.Exec.Screenshot(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return []types.ToolResultContentBlock{
+			&types.ToolResultContentBlockMemberImage{
+				Value: types.ImageBlock{
+					Format: types.ImageFormatPng,
+					Source: &types.ImageSourceMemberBytes{Value: png},
+				},
+			},
+		}, nil
+	case "mouse_move", "left_click":
+		x, y, err := coordinate(input)
+		if err != nil {
+			return nil, err
+		}
+		if action == "mouse_move" {
+			err = t.Exec.MouseMove(ctx, x, y)
+		} else {
+			err = t.Exec.Click(ctx, x, y)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return textBlock(fmt.Sprintf("%s at (%d, %d)", action, x, y)), nil
+	case "type":
+		text, _ := input["text"].(string)
+		if err := t.Exec.Type(ctx, text); err != nil {
+			return nil, err
+		}
+		return textBlock("typed"), nil
+	case "key":
+		key, _ := input["text"].(string)
+		if err := t.Exec.Key(ctx, key); err != nil {
+			return nil, err
+		}
+		return textBlock("pressed " + key), nil
+	default:
+		return nil, fmt.Errorf("computer: unsupported action %q", action)
+	}
+}
+
+func coordinate(input map[string]any) (int, int, error) {
+	raw, ok := input["coordinate"].([]any)
+	if !ok || len(raw) != 2 {
+		return 0, 0, fmt.Errorf("computer: coordinate [x, y] required")
+	}
+	x, xok := asInt(raw[0])
+	y, yok := asInt(raw[1])
+	if !xok || !yok {
+		return 0, 0, fmt.Errorf("computer: coordinate must be numeric")
+	}
+	return x, y, nil
+}
+
+func asInt(v any) (int, bool) {
+	switch n := v.(type) {
+	case float64:
+		return int(n), true
+	case int:
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+func textBlock(text string) []types.ToolResultContentBlock {
+	return []types.ToolResultContentBlock{
+		&types.ToolResultContentBlockMemberText{Value: text},
+	}
+}
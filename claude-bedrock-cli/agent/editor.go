@@ -0,0 +1,234 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Editor implements Anthropic's text-editor tool (view, create, str_replace,
+// insert, undo_edit) against a sandboxed directory, enabling multi-file code
+// edits through the agent loop.
+type Editor struct {
+	// Root confines all paths; references escaping it are rejected.
+	Root string
+	// DryRun previews every mutation as a diff-style summary instead of
+	// writing it.
+	DryRun bool
+
+	// history holds prior file contents for undo_edit, newest last. Files
+	// created in this session record an empty marker so undo removes them.
+	history map[string][]editorRevision
+}
+
+type editorRevision struct {
+	content string
+	existed bool
+}
+
+func (e *Editor) Name() string { return "str_replace_editor" }
+func (e *Editor) Description() string {
+	return "View and edit files: view, create, str_replace, insert, undo_edit."
+}
+
+// InputSchema is unused: the tool is declared through Declaration.
+func (e *Editor) InputSchema() map[string]any { return nil }
+
+// Declaration is the Anthropic-defined tool entry.
+func (e *Editor) Declaration() map[string]any {
+	return map[string]any{
+		"type": "text_editor_20241022",
+		"name": "str_replace_editor",
+	}
+}
+
+// Betas lists the beta flags the declaration requires.
+func (e *Editor) Betas() []string { return []string{ComputerUseBeta} }
+
+// Mutates marks the editor as a write-action unless it is previewing edits.
+func (e *Editor) Mutates() bool { return !e.DryRun }
+
+// resolve maps a tool-supplied path into the sandbox.
+func (e *Editor) resolve(path string) (string, error) {
+	if path == "" {
+		return "", fmt.Errorf("editor: path required")
+	}
+	full := filepath.Join(e.Root, filepath.FromSlash(strings.TrimPrefix(path, "/")))
+	rel, err := filepath.Rel(e.Root, full)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("editor: path %s escapes the sandbox", path)
+	}
+	return full, nil
+}
+
+func (e *Editor) remember(full string) {
+	if e.history == nil {
+		e.history = make(map[string][]editorRevision)
+	}
+	data, err := os.ReadFile(full)
+	if err != nil {
+		e.history[full] = append(e.history[full], editorRevision{existed: false})
+		return
+	}
+	e.history[full] = append(e.history[full], editorRevision{content: string(data), existed: true})
+}
+
+// Invoke executes one editor command.
+func (e *Editor) Invoke(ctx context.Context, input map[string]any) (string, error) {
+	command, _ := input["command"].(string)
+	path, _ := input["path"].(string)
+	full, err := e.resolve(path)
+	if err != nil {
+		return "", err
+	}
+
+	switch command {
+	case "view":
+		return e.view(full, input)
+	case "create":
+		text, _ := input["file_text"].(string)
+		if e.DryRun {
+			return fmt.Sprintf("dry-run: would create %s:\n%s", path, prefixLines(text, "+ ")), nil
+		}
+		e.remember(full)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(full, []byte(text), 0o644); err != nil {
+			return "", err
+		}
+		return "created " + path, nil
+	case "str_replace":
+		oldStr, _ := input["old_str"].(string)
+		newStr, _ := input["new_str"].(string)
+		return e.strReplace(full, path, oldStr, newStr)
+	case "insert":
+		line, ok := asInt(input["insert_line"])
+		if !ok {
+			return "", fmt.Errorf("editor: insert_line required")
+		}
+		newStr, _ := input["new_str"].(string)
+		return e.insert(full, path, line, newStr)
+	case "undo_edit":
+		return e.undo(full, path)
+	default:
+		return "", fmt.Errorf("editor: unsupported command %q", command)
+	}
+}
+
+func (e *Editor) view(full string, input map[string]any) (string, error) {
+	info, err := os.Stat(full)
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() {
+		entries, err := os.ReadDir(full)
+		if err != nil {
+			return "", err
+		}
+		var sb strings.Builder
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() {
+				name += "/"
+			}
+			sb.WriteString(name + "\n")
+		}
+		return sb.String(), nil
+	}
+	data, err := os.ReadFile(full)
+	if err != nil {
+		return "", err
+	}
+	lines := strings.Split(string(data), "\n")
+	from, to := 1, len(lines)
+	if r, ok := input["view_range"].([]any); ok && len(r) == 2 {
+		if v, ok := asInt(r[0]); ok && v >= 1 {
+			from = v
+		}
+		if v, ok := asInt(r[1]); ok && v >= from && v <= len(lines) {
+			to = v
+		}
+	}
+	var sb strings.Builder
+	for i := from; i <= to && i <= len(lines); i++ {
+		fmt.Fprintf(&sb, "%6d\t%s\n", i, lines[i-1])
+	}
+	return sb.String(), nil
+}
+
+func (e *Editor) strReplace(full, path, oldStr, newStr string) (string, error) {
+	if oldStr == "" {
+		return "", fmt.Errorf("editor: old_str required")
+	}
+	data, err := os.ReadFile(full)
+	if err != nil {
+		return "", err
+	}
+	content := string(data)
+	switch strings.Count(content, oldStr) {
+	case 0:
+		return "", fmt.Errorf("editor: old_str not found in %s", path)
+	case 1:
+	default:
+		return "", fmt.Errorf("editor: old_str appears multiple times in %s; add surrounding context", path)
+	}
+	if e.DryRun {
+		return fmt.Sprintf("dry-run: %s:\n%s%s", path,
+			prefixLines(oldStr, "- "), prefixLines(newStr, "+ ")), nil
+	}
+	e.remember(full)
+	if err := os.WriteFile(full, []byte(strings.Replace(content, oldStr, newStr, 1)), 0o644); err != nil {
+		return "", err
+	}
+	return "edited " + path, nil
+}
+
+func (e *Editor) insert(full, path string, line int, newStr string) (string, error) {
+	data, err := os.ReadFile(full)
+	if err != nil {
+		return "", err
+	}
+	lines := strings.Split(string(data), "\n")
+	if line < 0 || line > len(lines) {
+		return "", fmt.Errorf("editor: insert_line %d out of range (file has %d lines)", line, len(lines))
+	}
+	if e.DryRun {
+		return fmt.Sprintf("dry-run: %s after line %d:\n%s", path, line, prefixLines(newStr, "+ ")), nil
+	}
+	e.remember(full)
+	updated := append(lines[:line:line], append(strings.Split(newStr, "\n"), lines[line:]...)...)
+	if err := os.WriteFile(full, []byte(strings.Join(updated, "\n")), 0o644); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("inserted into %s after line %d", path, line), nil
+}
+
+func (e *Editor) undo(full, path string) (string, error) {
+	revs := e.history[full]
+	if len(revs) == 0 {
+		return "", fmt.Errorf("editor: nothing to undo for %s", path)
+	}
+	last := revs[len(revs)-1]
+	e.history[full] = revs[:len(revs)-1]
+	if !last.existed {
+		if err := os.Remove(full); err != nil {
+			return "", err
+		}
+		return "removed " + path, nil
+	}
+	if err := os.WriteFile(full, []byte(last.content), 0o644); err != nil {
+		return "", err
+	}
+	return "reverted " + path, nil
+}
+
+func prefixLines(text, prefix string) string {
+	if text == "" {
+		return ""
+	}
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	return prefix + strings.Join(lines, "\n"+prefix) + "\n"
+}
@@ -0,0 +1,41 @@
+package agent
+
+// ToolEventKind identifies a point in a tool call's lifecycle.
+type ToolEventKind string
+
+const (
+	// ToolCallStarted fires when the model has requested a tool call.
+	ToolCallStarted ToolEventKind = "tool_call_started"
+	// ToolInputDelta fires for incremental tool input as it streams; only
+	// streaming drivers emit it.
+	ToolInputDelta ToolEventKind = "tool_input_delta"
+	// ToolExecuting fires just before the tool runs, after any approval.
+	ToolExecuting ToolEventKind = "tool_executing"
+	// ToolResultReady fires when the tool finished, with its output or
+	// error.
+	ToolResultReady ToolEventKind = "tool_result"
+)
+
+// ToolEvent describes one tool lifecycle transition, letting UIs render tool
+// activity live instead of a silent gap between model turns.
+type ToolEvent struct {
+	Kind ToolEventKind
+	// ID is the tool_use ID tying events of one call together.
+	ID string
+	// Tool is the tool name.
+	Tool string
+	// Input is the call input as JSON (ToolCallStarted, ToolExecuting) or
+	// the raw delta fragment (ToolInputDelta).
+	Input string
+	// Output is the tool result text (ToolResultReady).
+	Output string
+	// Err is the failure, if any (ToolResultReady).
+	Err error
+}
+
+// emit delivers an event to the configured hook, if any.
+func (a *Agent) emit(ev ToolEvent) {
+	if a.OnEvent != nil {
+		a.OnEvent(ev)
+	}
+}
@@ -0,0 +1,124 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+)
+
+// Role is a named specialist in an orchestration: its own system prompt,
+// optionally its own model, and optionally its own tools (a role with
+// tools runs a full agent loop for its turn).
+type Role struct {
+	Name   string
+	System string
+	Model  string
+	Tools  []Tool
+}
+
+// Turn records one role's contribution to an orchestrated run.
+type Turn struct {
+	Round  int
+	Role   string
+	Output string
+	Usage  claude.Usage
+}
+
+// DefaultRounds is how many passes an Orchestrator makes over its roles
+// when Rounds is zero.
+const DefaultRounds = 1
+
+// Orchestrator runs role-specialized agents against one task. The routing
+// policy is a fixed relay: roles run in order, each seeing the task plus
+// every earlier turn, so a critic's feedback reaches the writer that
+// follows it. Additional rounds repeat the sequence, letting late
+// feedback flow back to early roles; the final role's last output is the
+// consolidated answer.
+type Orchestrator struct {
+	Client *claude.Client
+	Roles  []Role
+	// Rounds is how many passes to make over the roles; 0 applies
+	// DefaultRounds.
+	Rounds int
+	// MaxTokens bounds each role's turn.
+	MaxTokens int32
+	// MaxSteps bounds the agent loop of roles that carry tools.
+	MaxSteps int
+	// Policy and Confirm govern tool use by roles with tools, as in Agent.
+	Policy  *Policy
+	Confirm func(tool, input string) bool
+	// OnTurn, when non-nil, observes each completed turn in order.
+	OnTurn func(Turn)
+}
+
+// Run executes the orchestration for the task and returns the final
+// role's last output, the full turn-by-turn trace, and accumulated usage.
+func (o *Orchestrator) Run(ctx context.Context, task string) (string, []Turn, error) {
+	if len(o.Roles) == 0 {
+		return "", nil, fmt.Errorf("orchestrator: no roles configured")
+	}
+	rounds := o.Rounds
+	if rounds <= 0 {
+		rounds = DefaultRounds
+	}
+	var trace []Turn
+	final := ""
+	for round := 1; round <= rounds; round++ {
+		for _, role := range o.Roles {
+			prompt := o.prompt(task, trace)
+			out, usage, err := o.turn(ctx, role, prompt)
+			if err != nil {
+				return "", trace, fmt.Errorf("orchestrator: round %d, role %s: %w", round, role.Name, err)
+			}
+			turn := Turn{Round: round, Role: role.Name, Output: out, Usage: usage}
+			trace = append(trace, turn)
+			final = out
+			if o.OnTurn != nil {
+				o.OnTurn(turn)
+			}
+		}
+	}
+	return final, trace, nil
+}
+
+// prompt renders the task and the transcript so far for the next role.
+func (o *Orchestrator) prompt(task string, trace []Turn) string {
+	var sb strings.Builder
+	sb.WriteString("<task>\n" + task + "\n</task>\n")
+	for _, t := range trace {
+		fmt.Fprintf(&sb, "\n<turn role=%q round=\"%d\">\n%s\n</turn>\n", t.Role, t.Round, t.Output)
+	}
+	sb.WriteString("\nContribute your part as your role prescribes, building on the turns above.")
+	return sb.String()
+}
+
+// turn runs one role: a plain model call, or an agent loop when the role
+// carries tools.
+func (o *Orchestrator) turn(ctx context.Context, role Role, prompt string) (string, claude.Usage, error) {
+	client := o.Client
+	if role.Model != "" {
+		client = client.WithModel(role.Model)
+	}
+	maxTokens := o.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = 4096
+	}
+	if len(role.Tools) > 0 {
+		sub := &Agent{
+			Client:   client,
+			Tools:    role.Tools,
+			Policy:   o.Policy,
+			Confirm:  o.Confirm,
+			MaxSteps: o.MaxSteps,
+		}
+		return sub.Run(ctx, role.System, prompt, maxTokens)
+	}
+	text, usage, err := client.ConverseUsage(ctx, claude.Request{
+		System:    role.System,
+		Messages:  []claude.Message{claude.UserMessage(prompt)},
+		MaxTokens: maxTokens,
+	})
+	return strings.TrimSpace(text), usage, err
+}
@@ -0,0 +1,142 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// PluginTool adapts an external executable into a Tool via a small
+// JSON-over-stdio protocol, so users add tools without recompiling:
+//
+//	<plugin> describe
+//	    prints {"name":..., "description":..., "input_schema":{...}, "mutates":bool}
+//	<plugin> invoke
+//	    reads the input object as JSON on stdin and prints
+//	    {"output": "..."} or {"error": "..."}
+//
+// A plugin that omits "mutates" is treated as mutating — a subprocess can do
+// anything — so gate_writes policies stay on the safe side.
+type PluginTool struct {
+	Path string
+
+	name        string
+	description string
+	schema      map[string]any
+	mutates     bool
+}
+
+type pluginDescription struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"input_schema"`
+	Mutates     *bool          `json:"mutates"`
+}
+
+// LoadPlugin runs the executable's describe step and returns the tool.
+func LoadPlugin(ctx context.Context, path string) (*PluginTool, error) {
+	out, err := exec.CommandContext(ctx, path, "describe").Output()
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s: describe: %w", path, err)
+	}
+	var desc pluginDescription
+	if err := json.Unmarshal(out, &desc); err != nil {
+		return nil, fmt.Errorf("plugin %s: describe output: %w", path, err)
+	}
+	if desc.Name == "" {
+		return nil, fmt.Errorf("plugin %s: describe output has no name", path)
+	}
+	if desc.InputSchema == nil {
+		desc.InputSchema = map[string]any{"type": "object"}
+	}
+	return &PluginTool{
+		Path:        path,
+		name:        desc.Name,
+		description: desc.Description,
+		schema:      desc.InputSchema,
+		mutates:     desc.Mutates == nil || *desc.Mutates,
+	}, nil
+}
+
+func (t *PluginTool) Name() string                { return t.name }
+func (t *PluginTool) Description() string         { return t.description }
+func (t *PluginTool) InputSchema() map[string]any { return t.schema }
+
+// Mutates marks the plugin as a write-action unless it declared otherwise.
+func (t *PluginTool) Mutates() bool { return t.mutates }
+
+func (t *PluginTool) Invoke(ctx context.Context, input map[string]any) (string, error) {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return "", fmt.Errorf("plugin %s: %w", t.name, err)
+	}
+	cmd := exec.CommandContext(ctx, t.Path, "invoke")
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("plugin %s: %w", t.name, err)
+	}
+	var res struct {
+		Output string `json:"output"`
+		Error  string `json:"error"`
+	}
+	if err := json.Unmarshal(out, &res); err != nil {
+		return "", fmt.Errorf("plugin %s: invoke output: %w", t.name, err)
+	}
+	if res.Error != "" {
+		return "", fmt.Errorf("plugin %s: %s", t.name, res.Error)
+	}
+	return res.Output, nil
+}
+
+// DiscoverPlugins loads every executable in dir as a subprocess plugin tool
+// and every .wasm file as a sandboxed WasmTool (wasmFS names the one
+// directory WASM modules may read; empty grants none). When dir is empty,
+// ~/.claude-bedrock-cli/plugins is used; a missing directory is not an
+// error. A plugin whose describe step fails is skipped with a warning
+// rather than breaking the whole toolset.
+func DiscoverPlugins(ctx context.Context, dir, wasmFS string) ([]Tool, error) {
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Join(home, ".claude-bedrock-cli", "plugins")
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var tools []Tool
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		var t Tool
+		if filepath.Ext(e.Name()) == ".wasm" {
+			t, err = LoadWasmTool(ctx, path, wasmFS)
+		} else {
+			info, statErr := e.Info()
+			if statErr != nil || info.Mode()&0o111 == 0 {
+				continue
+			}
+			t, err = LoadPlugin(ctx, path)
+		}
+		if err != nil {
+			log.Printf("warning: %v", err)
+			continue
+		}
+		tools = append(tools, t)
+	}
+	return tools, nil
+}
@@ -0,0 +1,87 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Permission is what the loop may do with one tool call.
+type Permission string
+
+const (
+	// Allow runs the tool without asking.
+	Allow Permission = "allow"
+	// Approve asks the configured Confirm callback before running.
+	Approve Permission = "approve"
+	// Deny refuses the call and tells the model so.
+	Deny Permission = "deny"
+)
+
+// Policy maps tool names to permissions. Tools without an entry get Default.
+// GateWrites escalates tools that report themselves as write-actions (see
+// WriteAction) from Allow to Approve, so state-changing calls always pause
+// for a human even under a permissive policy.
+type Policy struct {
+	Default    Permission            `json:"default"`
+	Tools      map[string]Permission `json:"tools"`
+	GateWrites bool                  `json:"gate_writes"`
+}
+
+// DefaultPolicy requires approval for everything except the calculator,
+// which is harmless.
+func DefaultPolicy() *Policy {
+	return &Policy{
+		Default: Approve,
+		Tools:   map[string]Permission{"calculator": Allow},
+	}
+}
+
+// LoadPolicy reads a policy JSON file of the form
+// {"default": "approve", "tools": {"shell": "deny"}}.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var p Policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("policy %s: %w", path, err)
+	}
+	if p.Default == "" {
+		p.Default = Approve
+	}
+	for name, perm := range p.Tools {
+		switch perm {
+		case Allow, Approve, Deny:
+		default:
+			return nil, fmt.Errorf("policy %s: tool %s: unknown permission %q", path, name, perm)
+		}
+	}
+	return &p, nil
+}
+
+// Decide returns the permission for a tool name.
+func (p *Policy) Decide(tool string) Permission {
+	if perm, ok := p.Tools[tool]; ok {
+		return perm
+	}
+	if p.Default == "" {
+		return Approve
+	}
+	return p.Default
+}
+
+// DecideFor is Decide with write-gating applied: when GateWrites is set and
+// the implementation reports it mutates state, Allow is escalated to
+// Approve. An explicit Deny always stands.
+func (p *Policy) DecideFor(name string, impl Tool) Permission {
+	perm := p.Decide(name)
+	if !p.GateWrites || perm != Allow {
+		return perm
+	}
+	if w, ok := impl.(WriteAction); ok && w.Mutates() {
+		return Approve
+	}
+	return perm
+}
@@ -0,0 +1,236 @@
+// Package agent runs a tool-use loop over the Converse API: the model calls
+// registered tools, the loop executes them under a permission policy, and
+// results feed back until the model produces a final answer.
+package agent
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/document"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+)
+
+// Tool is one capability the model may invoke.
+type Tool interface {
+	Name() string
+	Description() string
+	// InputSchema is a JSON Schema describing the tool's input object.
+	InputSchema() map[string]any
+	Invoke(ctx context.Context, input map[string]any) (string, error)
+}
+
+// BlockTool is implemented by tools whose results carry non-text content,
+// such as computer-use screenshots.
+type BlockTool interface {
+	Tool
+	InvokeBlocks(ctx context.Context, input map[string]any) ([]types.ToolResultContentBlock, error)
+}
+
+// WriteAction is implemented by tools whose invocation mutates state
+// outside the conversation (shell commands, file edits, outbound
+// messages). Policies with gate_writes escalate such tools to Approve,
+// pausing the loop for a human before anything is changed.
+type WriteAction interface {
+	Tool
+	Mutates() bool
+}
+
+// AnthropicDeclared is implemented by tools declared through Anthropic's
+// beta tool types (computer use and friends) instead of a JSON-schema
+// toolSpec.
+type AnthropicDeclared interface {
+	Tool
+	Declaration() map[string]any
+	Betas() []string
+}
+
+// Spec converts a Tool to the Converse API tool declaration.
+func Spec(t Tool) types.Tool {
+	return &types.ToolMemberToolSpec{
+		Value: types.ToolSpecification{
+			Name:        strPtr(t.Name()),
+			Description: strPtr(t.Description()),
+			InputSchema: &types.ToolInputSchemaMemberJson{
+				Value: document.NewLazyDocument(t.InputSchema()),
+			},
+		},
+	}
+}
+
+func strPtr(s string) *string { return &s }
+
+// Calculator evaluates basic arithmetic expressions.
+type Calculator struct{}
+
+func (Calculator) Name() string { return "calculator" }
+func (Calculator) Description() string {
+	return "Evaluate an arithmetic expression with +, -, *, /, and parentheses."
+}
+func (Calculator) InputSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"expression": map[string]any{"type": "string"},
+		},
+		"required": []string{"expression"},
+	}
+}
+
+func (Calculator) Invoke(ctx context.Context, input map[string]any) (string, error) {
+	expr, _ := input["expression"].(string)
+	if expr == "" {
+		return "", fmt.Errorf("calculator: expression required")
+	}
+	v, err := evalExpr(expr)
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64), nil
+}
+
+// Shell runs a command through the system shell. It is powerful enough that
+// the default permission policy requires approval for it.
+type Shell struct{}
+
+func (Shell) Name() string { return "shell" }
+func (Shell) Description() string {
+	return "Run a shell command on the local machine and return its combined output."
+}
+
+// Mutates marks the shell as a write-action: any command may change the
+// machine.
+func (Shell) Mutates() bool { return true }
+
+func (Shell) InputSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"command": map[string]any{"type": "string"},
+		},
+		"required": []string{"command"},
+	}
+}
+
+func (Shell) Invoke(ctx context.Context, input map[string]any) (string, error) {
+	command, _ := input["command"].(string)
+	if command == "" {
+		return "", fmt.Errorf("shell: command required")
+	}
+	out, err := exec.CommandContext(ctx, "sh", "-c", command).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("shell: %w\n%s", err, out)
+	}
+	return string(out), nil
+}
+
+// evalExpr is a small recursive-descent evaluator for +, -, *, /, and
+// parentheses, enough for the calculator tool without pulling in a
+// dependency.
+func evalExpr(input string) (float64, error) {
+	p := &exprParser{s: strings.TrimSpace(input)}
+	v, err := p.sum()
+	if err != nil {
+		return 0, err
+	}
+	p.skipSpace()
+	if p.i < len(p.s) {
+		return 0, fmt.Errorf("calculator: unexpected %q", p.s[p.i:])
+	}
+	return v, nil
+}
+
+type exprParser struct {
+	s string
+	i int
+}
+
+func (p *exprParser) skipSpace() {
+	for p.i < len(p.s) && p.s[p.i] == ' ' {
+		p.i++
+	}
+}
+
+func (p *exprParser) sum() (float64, error) {
+	v, err := p.product()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		p.skipSpace()
+		if p.i >= len(p.s) || (p.s[p.i] != '+' && p.s[p.i] != '-') {
+			return v, nil
+		}
+		op := p.s[p.i]
+		p.i++
+		rhs, err := p.product()
+		if err != nil {
+			return 0, err
+		}
+		if op == '+' {
+			v += rhs
+		} else {
+			v -= rhs
+		}
+	}
+}
+
+func (p *exprParser) product() (float64, error) {
+	v, err := p.atom()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		p.skipSpace()
+		if p.i >= len(p.s) || (p.s[p.i] != '*' && p.s[p.i] != '/') {
+			return v, nil
+		}
+		op := p.s[p.i]
+		p.i++
+		rhs, err := p.atom()
+		if err != nil {
+			return 0, err
+		}
+		if op == '*' {
+			v *= rhs
+		} else {
+			if rhs == 0 {
+				return 0, fmt.Errorf("calculator: division by zero")
+			}
+			v /= rhs
+		}
+	}
+}
+
+func (p *exprParser) atom() (float64, error) {
+	p.skipSpace()
+	if p.i < len(p.s) && p.s[p.i] == '(' {
+		p.i++
+		v, err := p.sum()
+		if err != nil {
+			return 0, err
+		}
+		p.skipSpace()
+		if p.i >= len(p.s) || p.s[p.i] != ')' {
+			return 0, fmt.Errorf("calculator: missing )")
+		}
+		p.i++
+		return v, nil
+	}
+	if p.i < len(p.s) && p.s[p.i] == '-' {
+		p.i++
+		v, err := p.atom()
+		return -v, err
+	}
+	start := p.i
+	for p.i < len(p.s) && (p.s[p.i] >= '0' && p.s[p.i] <= '9' || p.s[p.i] == '.') {
+		p.i++
+	}
+	if start == p.i {
+		return 0, fmt.Errorf("calculator: expected number at %q", p.s[start:])
+	}
+	return strconv.ParseFloat(p.s[start:p.i], 64)
+}
@@ -0,0 +1,132 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+)
+
+// Span is one timed step of an agentic run: a model turn or a tool call.
+// Tool spans carry the ID of the model turn that requested them in Parent.
+type Span struct {
+	ID           int       `json:"id"`
+	Parent       int       `json:"parent,omitempty"`
+	Kind         string    `json:"kind"` // "model" or "tool"
+	Name         string    `json:"name"`
+	Input        string    `json:"input,omitempty"`
+	Output       string    `json:"output,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	Start        time.Time `json:"start"`
+	DurationMS   int64     `json:"duration_ms"`
+	InputTokens  int32     `json:"input_tokens,omitempty"`
+	OutputTokens int32     `json:"output_tokens,omitempty"`
+}
+
+// Trace records the spans of an agentic run in execution order, for export
+// as JSON or as a Graphviz/Mermaid graph. A nil *Trace discards everything,
+// so the agent can record unconditionally. Trace is not goroutine-safe;
+// the agent loop runs spans one at a time.
+type Trace struct {
+	Spans []Span
+}
+
+// begin opens a span and returns its ID.
+func (t *Trace) begin(kind, name, input string, parent int) int {
+	if t == nil {
+		return 0
+	}
+	t.Spans = append(t.Spans, Span{
+		ID:     len(t.Spans) + 1,
+		Parent: parent,
+		Kind:   kind,
+		Name:   name,
+		Input:  input,
+		Start:  time.Now(),
+	})
+	return len(t.Spans)
+}
+
+// end closes the span, recording its outcome and duration.
+func (t *Trace) end(id int, output string, usage *claude.Usage, err error) {
+	if t == nil || id < 1 || id > len(t.Spans) {
+		return
+	}
+	s := &t.Spans[id-1]
+	s.DurationMS = time.Since(s.Start).Milliseconds()
+	s.Output = output
+	if usage != nil {
+		s.InputTokens = usage.InputTokens
+		s.OutputTokens = usage.OutputTokens
+	}
+	if err != nil {
+		s.Error = err.Error()
+	}
+}
+
+// WriteJSON emits the trace as indented JSON.
+func (t *Trace) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(t.Spans)
+}
+
+// Mermaid renders the trace as a Mermaid flowchart: model turns in
+// sequence, tool calls branching off the turn that requested them.
+func (t *Trace) Mermaid() string {
+	var sb strings.Builder
+	sb.WriteString("flowchart TD\n")
+	prevRoot := 0
+	for _, s := range t.Spans {
+		fmt.Fprintf(&sb, "  s%d[%q]\n", s.ID, t.label(s))
+		switch {
+		case s.Parent != 0:
+			fmt.Fprintf(&sb, "  s%d --> s%d\n", s.Parent, s.ID)
+		case prevRoot != 0:
+			fmt.Fprintf(&sb, "  s%d --> s%d\n", prevRoot, s.ID)
+		}
+		if s.Parent == 0 {
+			prevRoot = s.ID
+		}
+	}
+	return sb.String()
+}
+
+// DOT renders the trace as a Graphviz digraph with the same shape as
+// Mermaid.
+func (t *Trace) DOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph trace {\n  rankdir=TB;\n  node [shape=box];\n")
+	prevRoot := 0
+	for _, s := range t.Spans {
+		fmt.Fprintf(&sb, "  s%d [label=%q];\n", s.ID, t.label(s))
+		switch {
+		case s.Parent != 0:
+			fmt.Fprintf(&sb, "  s%d -> s%d;\n", s.Parent, s.ID)
+		case prevRoot != 0:
+			fmt.Fprintf(&sb, "  s%d -> s%d;\n", prevRoot, s.ID)
+		}
+		if s.Parent == 0 {
+			prevRoot = s.ID
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// label summarizes a span for graph nodes: what ran, how long it took, and
+// what it cost.
+func (t *Trace) label(s Span) string {
+	label := fmt.Sprintf("%s %s (%dms", s.Kind, s.Name, s.DurationMS)
+	if s.Kind == "model" {
+		label += fmt.Sprintf(", %d in/%d out", s.InputTokens, s.OutputTokens)
+	}
+	label += ")"
+	if s.Error != "" {
+		label += " FAILED"
+	}
+	return label
+}
@@ -0,0 +1,113 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"github.com/tetratelabs/wazero/sys"
+)
+
+// WasmTool runs a tool packaged as a WebAssembly module under an embedded
+// runtime. The module speaks the same describe/invoke protocol as
+// subprocess plugins, over WASI stdio — but unlike a subprocess it executes
+// in a sandbox with no ambient capabilities: no network, no environment,
+// no clock beyond WASI's, and only the filesystem subtree explicitly
+// granted through FSRoot, mounted read-only. That makes WASM the tool
+// packaging to prefer in multi-tenant server deployments, where arbitrary
+// subprocess tools are not acceptable.
+type WasmTool struct {
+	Path string
+	// FSRoot, when non-empty, is the one directory the module may read; it
+	// appears as / inside the sandbox. Empty grants no filesystem at all.
+	FSRoot string
+
+	module      []byte
+	name        string
+	description string
+	schema      map[string]any
+}
+
+// LoadWasmTool reads the module and runs its describe step in the sandbox.
+func LoadWasmTool(ctx context.Context, path, fsRoot string) (*WasmTool, error) {
+	module, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	t := &WasmTool{Path: path, FSRoot: fsRoot, module: module}
+	out, err := t.run(ctx, "describe", nil)
+	if err != nil {
+		return nil, fmt.Errorf("wasm plugin %s: describe: %w", path, err)
+	}
+	var desc pluginDescription
+	if err := json.Unmarshal(out, &desc); err != nil {
+		return nil, fmt.Errorf("wasm plugin %s: describe output: %w", path, err)
+	}
+	if desc.Name == "" {
+		return nil, fmt.Errorf("wasm plugin %s: describe output has no name", path)
+	}
+	if desc.InputSchema == nil {
+		desc.InputSchema = map[string]any{"type": "object"}
+	}
+	t.name, t.description, t.schema = desc.Name, desc.Description, desc.InputSchema
+	return t, nil
+}
+
+func (t *WasmTool) Name() string                { return t.name }
+func (t *WasmTool) Description() string         { return t.description }
+func (t *WasmTool) InputSchema() map[string]any { return t.schema }
+
+func (t *WasmTool) Invoke(ctx context.Context, input map[string]any) (string, error) {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return "", fmt.Errorf("wasm plugin %s: %w", t.name, err)
+	}
+	out, err := t.run(ctx, "invoke", data)
+	if err != nil {
+		return "", fmt.Errorf("wasm plugin %s: %w", t.name, err)
+	}
+	var res struct {
+		Output string `json:"output"`
+		Error  string `json:"error"`
+	}
+	if err := json.Unmarshal(out, &res); err != nil {
+		return "", fmt.Errorf("wasm plugin %s: invoke output: %w", t.name, err)
+	}
+	if res.Error != "" {
+		return "", fmt.Errorf("wasm plugin %s: %s", t.name, res.Error)
+	}
+	return res.Output, nil
+}
+
+// run instantiates the module in a fresh runtime for one call, so no state
+// or capability leaks between invocations, and returns its stdout.
+func (t *WasmTool) run(ctx context.Context, arg string, stdin []byte) ([]byte, error) {
+	rt := wazero.NewRuntime(ctx)
+	defer rt.Close(ctx)
+	wasi_snapshot_preview1.MustInstantiate(ctx, rt)
+
+	var stdout bytes.Buffer
+	cfg := wazero.NewModuleConfig().
+		WithArgs(filepath.Base(t.Path), arg).
+		WithStdin(bytes.NewReader(stdin)).
+		WithStdout(&stdout).
+		WithStderr(os.Stderr)
+	if t.FSRoot != "" {
+		cfg = cfg.WithFSConfig(wazero.NewFSConfig().WithReadOnlyDirMount(t.FSRoot, "/"))
+	}
+	_, err := rt.InstantiateWithConfig(ctx, t.module, cfg)
+	var exit *sys.ExitError
+	if errors.As(err, &exit) && exit.ExitCode() == 0 {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+// archiveEntry is one file extracted from an archive, decoded to UTF-8.
+type archiveEntry struct {
+	Name string
+	Text string
+}
+
+// isArchive reports whether path looks like an archive readArchive can
+// open.
+func isArchive(p string) bool {
+	switch {
+	case strings.HasSuffix(p, ".zip"),
+		strings.HasSuffix(p, ".tar"),
+		strings.HasSuffix(p, ".tar.gz"),
+		strings.HasSuffix(p, ".tgz"):
+		return true
+	}
+	return false
+}
+
+// readArchive extracts the archive's file entries in memory, keeping only
+// those that pass the include/exclude glob patterns. Patterns match the
+// entry's full path and its base name; exclude wins over include, and an
+// empty include list admits everything.
+func readArchive(p string, include, exclude []string) ([]archiveEntry, error) {
+	var entries []archiveEntry
+	add := func(name string, r io.Reader) error {
+		if !matchEntry(name, include, exclude) {
+			return nil
+		}
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return fmt.Errorf("reading %s from %s: %w", name, p, err)
+		}
+		entries = append(entries, archiveEntry{Name: name, Text: decodeText(data)})
+		return nil
+	}
+
+	if strings.HasSuffix(p, ".zip") {
+		zr, err := zip.OpenReader(p)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		for _, f := range zr.File {
+			if f.FileInfo().IsDir() {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return nil, fmt.Errorf("opening %s in %s: %w", f.Name, p, err)
+			}
+			err = add(f.Name, rc)
+			rc.Close()
+			if err != nil {
+				return nil, err
+			}
+		}
+		return entries, nil
+	}
+
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if strings.HasSuffix(p, ".tar.gz") || strings.HasSuffix(p, ".tgz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("decompressing %s: %w", p, err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", p, err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := add(hdr.Name, tr); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// matchEntry applies include/exclude globs to an archive entry name.
+func matchEntry(name string, include, exclude []string) bool {
+	for _, pattern := range exclude {
+		if globMatches(pattern, name) {
+			return false
+		}
+	}
+	if len(include) == 0 {
+		return true
+	}
+	for _, pattern := range include {
+		if globMatches(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func globMatches(pattern, name string) bool {
+	if ok, _ := path.Match(pattern, name); ok {
+		return true
+	}
+	ok, _ := path.Match(pattern, path.Base(name))
+	return ok
+}
+
+// splitPatterns turns a comma-separated flag value into a pattern list.
+func splitPatterns(s string) []string {
+	if s == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
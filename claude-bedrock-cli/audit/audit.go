@@ -0,0 +1,227 @@
+// Package audit records model invocations in a local SQLite database for
+// later inspection and usage reporting.
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS invocations (
+	id            INTEGER PRIMARY KEY AUTOINCREMENT,
+	time          TEXT NOT NULL,
+	command       TEXT NOT NULL,
+	model         TEXT NOT NULL,
+	prompt_sha256 TEXT NOT NULL,
+	prompt        TEXT,
+	response      TEXT,
+	input_tokens  INTEGER NOT NULL,
+	output_tokens INTEGER NOT NULL,
+	cost_usd      REAL,
+	latency_ms    INTEGER NOT NULL,
+	caller        TEXT,
+	request_id    TEXT,
+	tags          TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_invocations_time ON invocations(time);
+CREATE INDEX IF NOT EXISTS idx_invocations_model ON invocations(model);
+`
+
+// Record is one audited invocation. Prompt and Response are empty when the
+// log stores content hashes only.
+type Record struct {
+	ID           int64
+	Time         time.Time
+	Command      string
+	Model        string
+	PromptSHA256 string
+	Prompt       string
+	Response     string
+	InputTokens  int32
+	OutputTokens int32
+	CostUSD      float64
+	LatencyMs    int64
+	Caller       string
+	RequestID    string
+	// Tags holds cost-allocation key=value pairs, comma-separated in a
+	// deterministic order.
+	Tags string
+}
+
+// Log is an append-only audit log backed by SQLite.
+type Log struct {
+	db *sql.DB
+	// StoreContent controls whether raw prompt/response text is persisted in
+	// addition to the prompt hash.
+	StoreContent bool
+}
+
+// DefaultPath returns the default audit database location.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".claude-bedrock-cli", "audit.db"), nil
+}
+
+// Open opens (creating if necessary) the audit database at path, or at
+// DefaultPath when path is empty.
+func Open(path string) (*Log, error) {
+	if path == "" {
+		var err error
+		if path, err = DefaultPath(); err != nil {
+			return nil, err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("audit: opening %s: %w", path, err)
+	}
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("audit: initializing schema: %w", err)
+	}
+	// Databases created before the request_id and tags columns existed are
+	// migrated in place; the errors are ignored when a column is already
+	// present.
+	db.Exec(`ALTER TABLE invocations ADD COLUMN request_id TEXT`)
+	db.Exec(`ALTER TABLE invocations ADD COLUMN tags TEXT`)
+	return &Log{db: db}, nil
+}
+
+// Close closes the underlying database.
+func (l *Log) Close() error { return l.db.Close() }
+
+// Append writes one invocation record. When StoreContent is false the raw
+// prompt and response are dropped and only the prompt hash is kept.
+func (l *Log) Append(ctx context.Context, rec Record) error {
+	if rec.Time.IsZero() {
+		rec.Time = time.Now().UTC()
+	}
+	if rec.PromptSHA256 == "" {
+		sum := sha256.Sum256([]byte(rec.Prompt))
+		rec.PromptSHA256 = hex.EncodeToString(sum[:])
+	}
+	prompt, response := rec.Prompt, rec.Response
+	if !l.StoreContent {
+		prompt, response = "", ""
+	}
+	_, err := l.db.ExecContext(ctx, `
+		INSERT INTO invocations
+			(time, command, model, prompt_sha256, prompt, response,
+			 input_tokens, output_tokens, cost_usd, latency_ms, caller, request_id, tags)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		rec.Time.Format(time.RFC3339Nano), rec.Command, rec.Model,
+		rec.PromptSHA256, prompt, response,
+		rec.InputTokens, rec.OutputTokens, rec.CostUSD, rec.LatencyMs, rec.Caller, rec.RequestID, rec.Tags)
+	if err != nil {
+		return fmt.Errorf("audit: %w", err)
+	}
+	return nil
+}
+
+// UsageRow is one aggregated line of a usage report.
+type UsageRow struct {
+	Key          string
+	Invocations  int64
+	InputTokens  int64
+	OutputTokens int64
+	CostUSD      float64
+}
+
+// Aggregate sums token counts and cost across invocations newer than
+// since (zero means everything), grouped by "day", "model", or "command".
+func (l *Log) Aggregate(ctx context.Context, groupBy string, since time.Time) ([]UsageRow, error) {
+	var key string
+	switch groupBy {
+	case "day":
+		key = "substr(time, 1, 10)"
+	case "model":
+		key = "model"
+	case "command":
+		key = "command"
+	case "tag":
+		key = "COALESCE(tags, '')"
+	default:
+		return nil, fmt.Errorf("audit: unknown grouping %q (have day, model, command, tag)", groupBy)
+	}
+	q := `SELECT ` + key + `, COUNT(*), SUM(input_tokens), SUM(output_tokens),
+			SUM(COALESCE(cost_usd, 0))
+		FROM invocations WHERE 1=1`
+	var args []any
+	if !since.IsZero() {
+		q += " AND time >= ?"
+		args = append(args, since.UTC().Format(time.RFC3339Nano))
+	}
+	q += " GROUP BY 1 ORDER BY 1"
+
+	rows, err := l.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("audit: %w", err)
+	}
+	defer rows.Close()
+
+	var out []UsageRow
+	for rows.Next() {
+		var row UsageRow
+		if err := rows.Scan(&row.Key, &row.Invocations, &row.InputTokens,
+			&row.OutputTokens, &row.CostUSD); err != nil {
+			return nil, err
+		}
+		out = append(out, row)
+	}
+	return out, rows.Err()
+}
+
+// Query returns up to limit records, newest first, optionally filtered by
+// model substring and a lower time bound.
+func (l *Log) Query(ctx context.Context, limit int, model string, since time.Time) ([]Record, error) {
+	q := `SELECT id, time, command, model, prompt_sha256, prompt, response,
+			input_tokens, output_tokens, cost_usd, latency_ms, caller,
+			COALESCE(request_id, ''), COALESCE(tags, '')
+		FROM invocations WHERE 1=1`
+	var args []any
+	if model != "" {
+		q += " AND model LIKE ?"
+		args = append(args, "%"+model+"%")
+	}
+	if !since.IsZero() {
+		q += " AND time >= ?"
+		args = append(args, since.UTC().Format(time.RFC3339Nano))
+	}
+	q += " ORDER BY id DESC LIMIT ?"
+	args = append(args, limit)
+
+	rows, err := l.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("audit: %w", err)
+	}
+	defer rows.Close()
+
+	var recs []Record
+	for rows.Next() {
+		var rec Record
+		var ts string
+		if err := rows.Scan(&rec.ID, &ts, &rec.Command, &rec.Model, &rec.PromptSHA256,
+			&rec.Prompt, &rec.Response, &rec.InputTokens, &rec.OutputTokens,
+			&rec.CostUSD, &rec.LatencyMs, &rec.Caller, &rec.RequestID, &rec.Tags); err != nil {
+			return nil, err
+		}
+		rec.Time, _ = time.Parse(time.RFC3339Nano, ts)
+		recs = append(recs, rec)
+	}
+	return recs, rows.Err()
+}
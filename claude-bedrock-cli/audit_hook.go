@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/user"
+	"time"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/audit"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+)
+
+// Auditing is opt-in: CLAUDE_CLI_AUDIT=1 enables it (hashes only), and
+// CLAUDE_CLI_AUDIT_CONTENT=1 additionally stores raw prompt/response text.
+// CLAUDE_CLI_AUDIT_DB overrides the database path.
+func auditEnabled() bool {
+	return os.Getenv("CLAUDE_CLI_AUDIT") == "1"
+}
+
+// recordInvocation appends an audit record when auditing is enabled; audit
+// failures are logged, never fatal to the invocation itself.
+func recordInvocation(ctx context.Context, command, model, prompt, response, requestID string, usage claude.Usage, latency time.Duration) {
+	if !auditEnabled() {
+		return
+	}
+	alog, err := audit.Open(os.Getenv("CLAUDE_CLI_AUDIT_DB"))
+	if err != nil {
+		log.Printf("warning: audit: %v", err)
+		return
+	}
+	defer alog.Close()
+	alog.StoreContent = os.Getenv("CLAUDE_CLI_AUDIT_CONTENT") == "1"
+
+	caller := ""
+	if u, err := user.Current(); err == nil {
+		caller = u.Username
+	}
+	cost, _ := claude.EstimateCostTier(model, usage, invocationTier)
+	err = alog.Append(ctx, audit.Record{
+		Command:      command,
+		Model:        model,
+		Prompt:       redactForStorage(ctx, prompt),
+		Response:     redactForStorage(ctx, response),
+		InputTokens:  usage.InputTokens,
+		OutputTokens: usage.OutputTokens,
+		CostUSD:      cost,
+		LatencyMs:    latency.Milliseconds(),
+		Caller:       caller,
+		RequestID:    requestID,
+		Tags:         formatTags(invocationTags),
+	})
+	if err != nil {
+		log.Printf("warning: audit: %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+// Package chunk splits long documents into model-sized pieces.
+package chunk
+
+import "strings"
+
+// Split breaks text into chunks of at most maxChars characters. It prefers
+// splitting on blank lines, then on single newlines, so paragraphs and
+// formatting survive intact; only a single run longer than maxChars is cut
+// mid-text.
+func Split(text string, maxChars int) []string {
+	if len(text) <= maxChars {
+		return []string{text}
+	}
+	var chunks []string
+	var cur strings.Builder
+	for _, para := range strings.SplitAfter(text, "\n\n") {
+		if cur.Len() > 0 && cur.Len()+len(para) > maxChars {
+			chunks = append(chunks, cur.String())
+			cur.Reset()
+		}
+		if len(para) > maxChars {
+			for _, line := range strings.SplitAfter(para, "\n") {
+				if cur.Len() > 0 && cur.Len()+len(line) > maxChars {
+					chunks = append(chunks, cur.String())
+					cur.Reset()
+				}
+				for len(line) > maxChars {
+					chunks = append(chunks, line[:maxChars])
+					line = line[maxChars:]
+				}
+				cur.WriteString(line)
+			}
+			continue
+		}
+		cur.WriteString(para)
+	}
+	if cur.Len() > 0 {
+		chunks = append(chunks, cur.String())
+	}
+	return chunks
+}
+
+// Piece is a chunk together with its byte offset in the source document.
+type Piece struct {
+	Start int
+	Text  string
+}
+
+// SplitWithOffsets is Split, but each chunk carries its starting byte offset
+// so downstream consumers (e.g. citations) can point back into the source.
+func SplitWithOffsets(text string, maxChars int) []Piece {
+	var pieces []Piece
+	offset := 0
+	for _, c := range Split(text, maxChars) {
+		pieces = append(pieces, Piece{Start: offset, Text: c})
+		offset += len(c)
+	}
+	return pieces
+}
@@ -0,0 +1,272 @@
+package chunk
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Splitter is a pluggable chunking strategy. Implementations that need no
+// I/O ignore ctx and never fail; the semantic splitter embeds sentences
+// and can do both.
+type Splitter interface {
+	Split(ctx context.Context, text string) ([]string, error)
+}
+
+// Embedder is the slice of the embeddings client the semantic splitter
+// needs.
+type Embedder interface {
+	Embed(ctx context.Context, text string) ([]float64, error)
+}
+
+// NewSplitter builds a named strategy: "paragraph" (the default
+// blank-line splitter), "sentence", "token" (fixed approximate-token
+// windows with overlap), or "markdown" (heading-aware). The "semantic"
+// strategy needs an embedder; use NewSemantic directly.
+func NewSplitter(name string, maxChars int) (Splitter, error) {
+	switch name {
+	case "", "paragraph":
+		return Paragraphs{MaxChars: maxChars}, nil
+	case "sentence":
+		return Sentences{MaxChars: maxChars}, nil
+	case "token":
+		// ~4 chars per token; a tenth of the window as overlap.
+		return TokenWindows{Tokens: maxChars / 4, Overlap: maxChars / 40}, nil
+	case "markdown":
+		return MarkdownHeadings{MaxChars: maxChars}, nil
+	default:
+		return nil, fmt.Errorf("chunk: unknown strategy %q (have paragraph, sentence, token, markdown, semantic)", name)
+	}
+}
+
+// Paragraphs splits on blank lines, the package's original strategy.
+type Paragraphs struct {
+	MaxChars int
+}
+
+func (p Paragraphs) Split(ctx context.Context, text string) ([]string, error) {
+	return Split(text, p.MaxChars), nil
+}
+
+// Sentences accumulates whole sentences up to MaxChars, so no chunk ends
+// mid-sentence; a single sentence longer than MaxChars is cut.
+type Sentences struct {
+	MaxChars int
+}
+
+func (s Sentences) Split(ctx context.Context, text string) ([]string, error) {
+	var chunks []string
+	var cur strings.Builder
+	for _, sentence := range splitSentences(text) {
+		if cur.Len() > 0 && cur.Len()+len(sentence) > s.MaxChars {
+			chunks = append(chunks, cur.String())
+			cur.Reset()
+		}
+		if len(sentence) > s.MaxChars {
+			chunks = append(chunks, Split(sentence, s.MaxChars)...)
+			continue
+		}
+		cur.WriteString(sentence)
+	}
+	if cur.Len() > 0 {
+		chunks = append(chunks, cur.String())
+	}
+	return chunks, nil
+}
+
+// TokenWindows emits fixed windows of approximately Tokens tokens
+// (estimated at four characters each) with Overlap tokens repeated
+// between consecutive windows, cutting at word boundaries.
+type TokenWindows struct {
+	Tokens  int
+	Overlap int
+}
+
+func (t TokenWindows) Split(ctx context.Context, text string) ([]string, error) {
+	window := t.Tokens * 4
+	overlap := t.Overlap * 4
+	if window <= 0 {
+		return nil, fmt.Errorf("chunk: token window must be positive")
+	}
+	if overlap >= window {
+		return nil, fmt.Errorf("chunk: overlap must be smaller than the window")
+	}
+	if len(text) <= window {
+		return []string{text}, nil
+	}
+	var chunks []string
+	for start := 0; start < len(text); {
+		end := start + window
+		if end >= len(text) {
+			chunks = append(chunks, text[start:])
+			break
+		}
+		// Back off to the last space so words stay whole — but only while
+		// that still moves the next window (start advances to end-overlap)
+		// past this one. A space at or before the overlap, as in a short
+		// word followed by an unbroken URL or base64 run, would otherwise
+		// re-emit the same window forever; those runs get a hard cut.
+		if cut := strings.LastIndexByte(text[start:end], ' '); cut > overlap {
+			end = start + cut
+		}
+		chunks = append(chunks, text[start:end])
+		start = end - overlap
+		if start < 0 {
+			start = 0
+		}
+	}
+	return chunks, nil
+}
+
+// MarkdownHeadings cuts at heading lines so each chunk covers whole
+// sections; oversized sections fall back to paragraph splitting.
+type MarkdownHeadings struct {
+	MaxChars int
+}
+
+func (m MarkdownHeadings) Split(ctx context.Context, text string) ([]string, error) {
+	var sections []string
+	var cur strings.Builder
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if strings.HasPrefix(line, "#") && cur.Len() > 0 {
+			sections = append(sections, cur.String())
+			cur.Reset()
+		}
+		cur.WriteString(line)
+	}
+	if cur.Len() > 0 {
+		sections = append(sections, cur.String())
+	}
+
+	var chunks []string
+	var acc strings.Builder
+	for _, section := range sections {
+		if acc.Len() > 0 && acc.Len()+len(section) > m.MaxChars {
+			chunks = append(chunks, acc.String())
+			acc.Reset()
+		}
+		if len(section) > m.MaxChars {
+			chunks = append(chunks, Split(section, m.MaxChars)...)
+			continue
+		}
+		acc.WriteString(section)
+	}
+	if acc.Len() > 0 {
+		chunks = append(chunks, acc.String())
+	}
+	return chunks, nil
+}
+
+// Semantic groups consecutive sentences while they stay similar to the
+// running chunk, starting a new chunk at topic shifts or the size cap.
+type Semantic struct {
+	Embedder Embedder
+	MaxChars int
+	// Threshold is the cosine similarity below which a sentence starts a
+	// new chunk; 0 applies a moderate default.
+	Threshold float64
+}
+
+// defaultSemanticThreshold splits on clear topic shifts without
+// fragmenting ordinary prose.
+const defaultSemanticThreshold = 0.55
+
+// NewSemantic builds the embedding-based strategy.
+func NewSemantic(embedder Embedder, maxChars int) Semantic {
+	return Semantic{Embedder: embedder, MaxChars: maxChars}
+}
+
+func (s Semantic) Split(ctx context.Context, text string) ([]string, error) {
+	threshold := s.Threshold
+	if threshold == 0 {
+		threshold = defaultSemanticThreshold
+	}
+	sentences := splitSentences(text)
+	var chunks []string
+	var cur strings.Builder
+	var centroid []float64
+	count := 0
+	flush := func() {
+		if cur.Len() > 0 {
+			chunks = append(chunks, cur.String())
+			cur.Reset()
+		}
+		centroid = nil
+		count = 0
+	}
+	for _, sentence := range sentences {
+		vec, err := s.Embedder.Embed(ctx, sentence)
+		if err != nil {
+			return nil, fmt.Errorf("chunk: embedding sentence: %w", err)
+		}
+		if cur.Len() > 0 && (cur.Len()+len(sentence) > s.MaxChars || cosine(centroid, vec) < threshold) {
+			flush()
+		}
+		cur.WriteString(sentence)
+		centroid = addScaled(centroid, vec, count)
+		count++
+	}
+	flush()
+	return chunks, nil
+}
+
+// splitSentences cuts text after terminal punctuation followed by
+// whitespace, keeping the trailing whitespace with the sentence.
+func splitSentences(text string) []string {
+	var sentences []string
+	start := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '.', '!', '?':
+			j := i + 1
+			for j < len(text) && (text[j] == '.' || text[j] == '!' || text[j] == '?') {
+				j++
+			}
+			if j < len(text) && text[j] != ' ' && text[j] != '\n' && text[j] != '\t' {
+				continue
+			}
+			for j < len(text) && (text[j] == ' ' || text[j] == '\n' || text[j] == '\t') {
+				j++
+			}
+			sentences = append(sentences, text[start:j])
+			start = j
+			i = j - 1
+		}
+	}
+	if start < len(text) {
+		sentences = append(sentences, text[start:])
+	}
+	return sentences
+}
+
+// cosine is the similarity between two vectors; duplicated from the embed
+// package to keep chunk free of that dependency.
+func cosine(a, b []float64) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
+
+// addScaled folds vec into a running mean of count prior vectors.
+func addScaled(mean, vec []float64, count int) []float64 {
+	if mean == nil {
+		out := make([]float64, len(vec))
+		copy(out, vec)
+		return out
+	}
+	for i := range mean {
+		mean[i] = (mean[i]*float64(count) + vec[i]) / float64(count+1)
+	}
+	return mean
+}
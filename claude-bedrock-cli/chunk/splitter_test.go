@@ -0,0 +1,209 @@
+package chunk
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSplitShortTextIsOneChunk(t *testing.T) {
+	got := Split("hello world", 100)
+	if len(got) != 1 || got[0] != "hello world" {
+		t.Fatalf("Split = %q, want the input unchanged", got)
+	}
+}
+
+func TestSplitPrefersParagraphBoundaries(t *testing.T) {
+	text := "first paragraph.\n\nsecond paragraph.\n\nthird paragraph."
+	chunks := Split(text, 25)
+	if len(chunks) < 2 {
+		t.Fatalf("Split = %d chunks, want a split", len(chunks))
+	}
+	for i, c := range chunks {
+		if len(c) > 25 {
+			t.Errorf("chunk %d is %d chars, over the cap", i, len(c))
+		}
+	}
+	if joined := strings.Join(chunks, ""); joined != text {
+		t.Fatalf("chunks do not reassemble the input:\n%q", joined)
+	}
+}
+
+func TestSplitCutsOversizedRuns(t *testing.T) {
+	text := strings.Repeat("x", 95)
+	chunks := Split(text, 30)
+	for i, c := range chunks {
+		if len(c) > 30 {
+			t.Errorf("chunk %d is %d chars, over the cap", i, len(c))
+		}
+	}
+	if strings.Join(chunks, "") != text {
+		t.Fatal("chunks do not reassemble the input")
+	}
+}
+
+func TestSplitWithOffsets(t *testing.T) {
+	text := "alpha.\n\nbeta.\n\ngamma."
+	for _, p := range SplitWithOffsets(text, 8) {
+		if text[p.Start:p.Start+len(p.Text)] != p.Text {
+			t.Errorf("offset %d does not point at %q in the source", p.Start, p.Text)
+		}
+	}
+}
+
+func TestNewSplitterNames(t *testing.T) {
+	for _, name := range []string{"", "paragraph", "sentence", "token", "markdown"} {
+		if _, err := NewSplitter(name, 400); err != nil {
+			t.Errorf("NewSplitter(%q): %v", name, err)
+		}
+	}
+	if _, err := NewSplitter("nope", 400); err == nil {
+		t.Error("NewSplitter accepted an unknown strategy")
+	}
+}
+
+func TestSentencesKeepsSentencesWhole(t *testing.T) {
+	text := "One two three. Four five six. Seven eight nine."
+	chunks, err := Sentences{MaxChars: 20}.Split(context.Background(), text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, c := range chunks {
+		trimmed := strings.TrimRight(c, " \n\t")
+		if !strings.HasSuffix(trimmed, ".") {
+			t.Errorf("chunk %d ends mid-sentence: %q", i, c)
+		}
+	}
+	if strings.Join(chunks, "") != text {
+		t.Fatal("chunks do not reassemble the input")
+	}
+}
+
+func TestTokenWindowsOverlap(t *testing.T) {
+	words := strings.Repeat("word ", 200)
+	chunks, err := TokenWindows{Tokens: 50, Overlap: 5}.Split(context.Background(), words)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chunks) < 2 {
+		t.Fatalf("got %d chunks, want several windows", len(chunks))
+	}
+	for i := 1; i < len(chunks); i++ {
+		tail := chunks[i-1][len(chunks[i-1])-10:]
+		if !strings.Contains(chunks[i], tail) {
+			t.Errorf("window %d does not repeat the tail of window %d", i, i-1)
+		}
+	}
+}
+
+func TestTokenWindowsUnbrokenRunAfterShortWord(t *testing.T) {
+	// A space inside the overlap used to move the window backwards and
+	// re-emit the same chunk forever. The splitter must terminate and
+	// still cover the whole input.
+	splitter, err := NewSplitter("token", 48000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := "a " + strings.Repeat("x", 50000)
+	chunks, err := splitter.Split(context.Background(), text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chunks) < 2 {
+		t.Fatalf("got %d chunks, want the run split across windows", len(chunks))
+	}
+	if !strings.HasSuffix(chunks[len(chunks)-1], "x") {
+		t.Error("final chunk does not reach the end of the input")
+	}
+	var covered int
+	for _, c := range chunks {
+		covered += len(c)
+	}
+	if covered < len(text) {
+		t.Errorf("chunks cover %d chars of %d", covered, len(text))
+	}
+}
+
+func TestTokenWindowsRejectsBadConfig(t *testing.T) {
+	if _, err := (TokenWindows{Tokens: 0}).Split(context.Background(), "text"); err == nil {
+		t.Error("zero window accepted")
+	}
+	if _, err := (TokenWindows{Tokens: 10, Overlap: 10}).Split(context.Background(), "text"); err == nil {
+		t.Error("overlap >= window accepted")
+	}
+}
+
+func TestMarkdownHeadingsCutAtHeadings(t *testing.T) {
+	text := "# One\nbody one\n## Two\nbody two\n# Three\nbody three\n"
+	chunks, err := MarkdownHeadings{MaxChars: 20}.Split(context.Background(), text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, c := range chunks[1:] {
+		if !strings.HasPrefix(c, "#") {
+			t.Errorf("chunk %d does not start at a heading: %q", i+1, c)
+		}
+	}
+	if strings.Join(chunks, "") != text {
+		t.Fatal("chunks do not reassemble the input")
+	}
+}
+
+func TestSplitSentences(t *testing.T) {
+	got := splitSentences("First one. Second two! Third three? Trailing")
+	want := []string{"First one. ", "Second two! ", "Third three? ", "Trailing"}
+	if len(got) != len(want) {
+		t.Fatalf("splitSentences = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("sentence %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestSplitSentencesKeepsAbbreviationsTogether(t *testing.T) {
+	// "3.5" has no space after the period, so it must not split.
+	got := splitSentences("Version 3.5 shipped. Done.")
+	if len(got) != 2 {
+		t.Fatalf("splitSentences = %q, want 2 sentences", got)
+	}
+}
+
+// constantEmbedder makes every sentence identical, so the semantic splitter
+// should only cut on the size cap.
+type constantEmbedder struct{}
+
+func (constantEmbedder) Embed(ctx context.Context, text string) ([]float64, error) {
+	return []float64{1, 0}, nil
+}
+
+// alternatingEmbedder flips between orthogonal vectors per call, so every
+// sentence looks like a topic shift.
+type alternatingEmbedder struct{ n int }
+
+func (e *alternatingEmbedder) Embed(ctx context.Context, text string) ([]float64, error) {
+	e.n++
+	if e.n%2 == 0 {
+		return []float64{0, 1}, nil
+	}
+	return []float64{1, 0}, nil
+}
+
+func TestSemanticSplitsOnTopicShift(t *testing.T) {
+	text := "Alpha one. Beta two. Gamma three. Delta four."
+	same, err := NewSemantic(constantEmbedder{}, 1000).Split(context.Background(), text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(same) != 1 {
+		t.Errorf("constant embedder: got %d chunks, want 1", len(same))
+	}
+	shifts, err := NewSemantic(&alternatingEmbedder{}, 1000).Split(context.Background(), text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shifts) < 2 {
+		t.Errorf("alternating embedder: got %d chunks, want a split per shift", len(shifts))
+	}
+}
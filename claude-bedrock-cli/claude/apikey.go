@@ -0,0 +1,60 @@
+package claude
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/secrets"
+)
+
+// Bedrock API keys are scoped bearer tokens issued by Bedrock itself, for
+// environments that hand out keys instead of IAM credentials. When one is
+// configured the client authenticates with it and skips SigV4 entirely.
+//
+// The key is read from CLAUDE_CLI_BEDROCK_API_KEY, which may be the key
+// itself or a secretsmanager:// / ssm:// reference so the token never sits
+// in shell history or plain config. AWS_BEARER_TOKEN_BEDROCK, the variable
+// the AWS SDKs standardised on, is honoured as a literal fallback.
+
+// bedrockAPIKey resolves the configured Bedrock API key, or "" when none is
+// set and the default SigV4 credential chain should be used.
+func bedrockAPIKey(ctx context.Context, region string) (string, error) {
+	key := os.Getenv("CLAUDE_CLI_BEDROCK_API_KEY")
+	if key == "" {
+		return os.Getenv("AWS_BEARER_TOKEN_BEDROCK"), nil
+	}
+	if !secrets.IsRef(key) {
+		return key, nil
+	}
+	resolver, err := secrets.NewResolver(ctx, region)
+	if err != nil {
+		return "", err
+	}
+	key, err = resolver.Resolve(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(key), nil
+}
+
+// withBearerAuth installs middleware that authenticates every request with
+// the API key. It runs at the end of the finalize step, so it wins over any
+// signature an earlier middleware may have attached.
+func withBearerAuth(key string) func(*bedrockruntime.Options) {
+	return func(o *bedrockruntime.Options) {
+		o.APIOptions = append(o.APIOptions, func(stack *middleware.Stack) error {
+			return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("BedrockAPIKey",
+				func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (middleware.FinalizeOutput, middleware.Metadata, error) {
+					if req, ok := in.Request.(*smithyhttp.Request); ok {
+						req.Header.Set("Authorization", "Bearer "+key)
+					}
+					return next.HandleFinalize(ctx, in)
+				}), middleware.After)
+		})
+	}
+}
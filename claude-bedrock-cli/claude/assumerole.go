@@ -0,0 +1,59 @@
+package claude
+
+import (
+	"context"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// AssumeRole chains the client's credentials into a role — typically in
+// another account — before invoking Bedrock, so a central deployment can
+// draw on a workload account's own Bedrock quota.
+type AssumeRole struct {
+	// RoleARN is the role to assume in the target account.
+	RoleARN string
+	// ExternalID satisfies a trust policy that demands one; empty omits it.
+	ExternalID string
+	// SessionName labels the STS session in the target account's CloudTrail;
+	// empty uses "claude-bedrock-cli".
+	SessionName string
+}
+
+// credentials wraps the base configuration's credentials in a cached
+// assume-role provider, refreshing the chained session as it expires.
+func (r AssumeRole) credentials(cfg aws.Config) aws.CredentialsProvider {
+	provider := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), r.RoleARN, func(o *stscreds.AssumeRoleOptions) {
+		o.RoleSessionName = r.SessionName
+		if o.RoleSessionName == "" {
+			o.RoleSessionName = "claude-bedrock-cli"
+		}
+		if r.ExternalID != "" {
+			o.ExternalID = aws.String(r.ExternalID)
+		}
+	})
+	return aws.NewCredentialsCache(provider)
+}
+
+// assumeRoleFromEnv reads CLAUDE_CLI_ASSUME_ROLE_ARN (and the optional
+// CLAUDE_CLI_ASSUME_ROLE_EXTERNAL_ID), so every command can invoke a
+// different account's Bedrock without per-command flags.
+func assumeRoleFromEnv() *AssumeRole {
+	arn := os.Getenv("CLAUDE_CLI_ASSUME_ROLE_ARN")
+	if arn == "" {
+		return nil
+	}
+	return &AssumeRole{
+		RoleARN:    arn,
+		ExternalID: os.Getenv("CLAUDE_CLI_ASSUME_ROLE_EXTERNAL_ID"),
+	}
+}
+
+// NewClientAssumeRole is NewClient with invocations running under the given
+// role. The role is assumed with the default credential chain; sessions are
+// cached and renewed automatically.
+func NewClientAssumeRole(ctx context.Context, region, modelID string, role AssumeRole) (*Client, error) {
+	return newClientHTTP(ctx, region, modelID, HTTPOptions{}, &role)
+}
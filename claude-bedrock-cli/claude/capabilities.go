@@ -0,0 +1,161 @@
+package claude
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+)
+
+// Capability records what a model family supports, so obviously invalid
+// requests fail locally with a clear message instead of an opaque service
+// validation error.
+type Capability struct {
+	// MaxContext is the input context window in tokens.
+	MaxContext int
+	// MaxOutput is the output-token ceiling with every supported beta
+	// enabled.
+	MaxOutput int32
+	Vision    bool
+	Tools     bool
+	Thinking  bool
+	Streaming bool
+	// Betas lists the anthropic_beta values the model accepts.
+	Betas []string
+}
+
+// Beta1MContext enables the extended 1M-token context window on models
+// that support it. Input beyond 200k tokens bills at the long-context
+// rate.
+const Beta1MContext = "context-1m-2025-08-07"
+
+// modelCapabilities is keyed by model ID substring, like modelPricing, so
+// cross-region inference profile prefixes still match. Best-effort: the
+// service remains authoritative for models not listed here.
+var modelCapabilities = map[string]Capability{
+	"claude-3-haiku": {
+		MaxContext: 200000, MaxOutput: 4096,
+		Vision: true, Tools: true, Streaming: true,
+	},
+	"claude-3-5-haiku": {
+		MaxContext: 200000, MaxOutput: 8192,
+		Tools: true, Streaming: true,
+	},
+	"claude-3-sonnet": {
+		MaxContext: 200000, MaxOutput: 4096,
+		Vision: true, Tools: true, Streaming: true,
+	},
+	"claude-3-5-sonnet": {
+		MaxContext: 200000, MaxOutput: 8192,
+		Vision: true, Tools: true, Streaming: true,
+		Betas: []string{"computer-use-2024-10-22"},
+	},
+	"claude-3-7-sonnet": {
+		MaxContext: 200000, MaxOutput: 131072,
+		Vision: true, Tools: true, Thinking: true, Streaming: true,
+		Betas: []string{
+			"computer-use-2025-01-24",
+			"output-128k-2025-02-19",
+			"token-efficient-tools-2025-02-19",
+		},
+	},
+	"claude-3-opus": {
+		MaxContext: 200000, MaxOutput: 4096,
+		Vision: true, Tools: true, Streaming: true,
+	},
+	"claude-sonnet-4": {
+		MaxContext: 1000000, MaxOutput: 65536,
+		Vision: true, Tools: true, Thinking: true, Streaming: true,
+		Betas: []string{Beta1MContext},
+	},
+
+	// Non-Anthropic Converse families, so comparison and routing across the
+	// catalog get the same local validation.
+	"nova-micro": {
+		MaxContext: 128000, MaxOutput: 5120,
+		Tools: true, Streaming: true,
+	},
+	"nova-lite": {
+		MaxContext: 300000, MaxOutput: 5120,
+		Vision: true, Tools: true, Streaming: true,
+	},
+	"nova-pro": {
+		MaxContext: 300000, MaxOutput: 5120,
+		Vision: true, Tools: true, Streaming: true,
+	},
+	"llama3-8b": {
+		MaxContext: 8192, MaxOutput: 2048,
+		Streaming: true,
+	},
+	"llama3-70b": {
+		MaxContext: 8192, MaxOutput: 2048,
+		Streaming: true,
+	},
+	"mistral-large": {
+		MaxContext: 32000, MaxOutput: 8192,
+		Tools: true, Streaming: true,
+	},
+	"mixtral-8x7b": {
+		MaxContext: 32000, MaxOutput: 4096,
+		Streaming: true,
+	},
+}
+
+// LookupCapability returns the capability entry matching the model ID, and
+// whether the model is in the registry.
+func LookupCapability(modelID string) (Capability, bool) {
+	for key, cap := range modelCapabilities {
+		if strings.Contains(modelID, key) {
+			return cap, true
+		}
+	}
+	return Capability{}, false
+}
+
+// ValidateRequest checks an assembled request against the capability
+// registry before invocation. Models not in the registry pass unchecked,
+// except that Anthropic-only features are rejected for other families.
+func ValidateRequest(modelID string, req Request) error {
+	if err := validateAnthropicFields(modelID, req); err != nil {
+		return err
+	}
+	cap, ok := LookupCapability(modelID)
+	if !ok {
+		return nil
+	}
+	if req.MaxTokens > cap.MaxOutput {
+		return fmt.Errorf("%s supports at most %d output tokens, not %d", modelID, cap.MaxOutput, req.MaxTokens)
+	}
+	if !cap.Tools && (len(req.Tools) > 0 || len(req.AnthropicTools) > 0) {
+		return fmt.Errorf("%s does not support tool use", modelID)
+	}
+	if !cap.Vision && hasImages(req.Messages) {
+		return fmt.Errorf("%s does not support image input", modelID)
+	}
+	for _, beta := range req.Betas {
+		if !supportsBeta(cap, beta) {
+			return fmt.Errorf("%s does not support %s beta", modelID, beta)
+		}
+	}
+	return nil
+}
+
+func supportsBeta(cap Capability, beta string) bool {
+	for _, b := range cap.Betas {
+		if b == beta {
+			return true
+		}
+	}
+	return false
+}
+
+func hasImages(messages []types.Message) bool {
+	for _, msg := range messages {
+		for _, block := range msg.Content {
+			if _, ok := block.(*types.ContentBlockMemberImage); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
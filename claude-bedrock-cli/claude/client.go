@@ -0,0 +1,598 @@
+// Package claude wraps the Bedrock Runtime Converse API with a small,
+// opinionated surface for invoking Anthropic Claude models from Go. Other
+// Converse-capable families (Nova, Llama, Mistral, ...) invoke and stream
+// through the same surface; see passthrough.go.
+package claude
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/document"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	"github.com/aws/smithy-go"
+)
+
+// DefaultModelID is used when no model is configured on the command line.
+const DefaultModelID = "anthropic.claude-3-5-sonnet-20241022-v2:0"
+
+// Client invokes Claude models through the Bedrock Runtime Converse API. It
+// is safe for concurrent use: one Client can drive many simultaneous
+// invocations, and WithModel views share its connection pool.
+type Client struct {
+	rt      *bedrockruntime.Client
+	ModelID string
+
+	// last records the most recent invocation's metadata. It is a pointer so
+	// WithModel copies share (and safely update) the same record.
+	last *lastState
+}
+
+// lastState is the mutex-guarded record behind LastRequestID and
+// LastStopReason.
+type lastState struct {
+	mu         sync.Mutex
+	requestID  string
+	stopReason types.StopReason
+}
+
+func (st *lastState) setRequestID(id string) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.requestID = id
+}
+
+func (st *lastState) setStopReason(reason types.StopReason) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.stopReason = reason
+}
+
+// LastRequestID is the Bedrock request ID of the most recent invocation,
+// usable to correlate with server-side invocation logs. Under concurrent
+// invocations it reflects whichever finished last.
+func (c *Client) LastRequestID() string {
+	c.last.mu.Lock()
+	defer c.last.mu.Unlock()
+	return c.last.requestID
+}
+
+// LastStopReason is why the most recent invocation stopped generating;
+// streaming callers need it to detect guardrail interventions after the
+// partial output has already been emitted.
+func (c *Client) LastStopReason() types.StopReason {
+	c.last.mu.Lock()
+	defer c.last.mu.Unlock()
+	return c.last.stopReason
+}
+
+// GuardrailIntervened reports whether the most recent invocation was cut
+// short by a guardrail.
+func (c *Client) GuardrailIntervened() bool {
+	return c.LastStopReason() == types.StopReasonGuardrailIntervened
+}
+
+// HTTPOptions tunes the HTTP client shared by all invocations through one
+// Client. The zero value keeps the SDK defaults.
+type HTTPOptions struct {
+	// Timeout bounds a whole request including body; leave 0 for streaming
+	// workloads, which must outlive any fixed deadline.
+	Timeout time.Duration
+	// MaxIdleConnsPerHost sizes the connection pool kept open to the Bedrock
+	// endpoint across requests.
+	MaxIdleConnsPerHost int
+	// ProxyURL forces an HTTPS proxy; empty defers to the standard
+	// HTTPS_PROXY/NO_PROXY environment handling.
+	ProxyURL string
+	// CABundle is a path to a PEM file of additional trusted CAs, for egress
+	// proxies that re-sign TLS. It supplements the AWS_CA_BUNDLE variable the
+	// SDK already honours.
+	CABundle string
+	// EndpointURL overrides the Bedrock Runtime endpoint, e.g. a PrivateLink
+	// VPC endpoint URL (https://vpce-...bedrock-runtime.us-east-1.vpce.amazonaws.com).
+	// Empty falls back to the CLAUDE_CLI_BEDROCK_ENDPOINT variable, then the
+	// regional default.
+	EndpointURL string
+	// UseFIPS selects the region's FIPS endpoint where one exists. False
+	// falls back to the CLAUDE_CLI_FIPS variable.
+	UseFIPS bool
+}
+
+// NewClient loads the default AWS configuration for the given region and
+// returns a client bound to modelID (DefaultModelID when empty).
+func NewClient(ctx context.Context, region, modelID string) (*Client, error) {
+	return NewClientHTTP(ctx, region, modelID, HTTPOptions{})
+}
+
+// NewClientHTTP is NewClient with explicit HTTP client tuning. The returned
+// Client (and the connection pool behind it) is intended to be created once
+// and shared; use WithModel for per-request model overrides.
+func NewClientHTTP(ctx context.Context, region, modelID string, httpOpts HTTPOptions) (*Client, error) {
+	return newClientHTTP(ctx, region, modelID, httpOpts, assumeRoleFromEnv())
+}
+
+func newClientHTTP(ctx context.Context, region, modelID string, httpOpts HTTPOptions, role *AssumeRole) (*Client, error) {
+	var opts []func(*awsconfig.LoadOptions) error
+	if region != "" {
+		opts = append(opts, awsconfig.WithRegion(region))
+	}
+	if httpOpts.UseFIPS || os.Getenv("CLAUDE_CLI_FIPS") == "1" {
+		opts = append(opts, awsconfig.WithUseFIPSEndpoint(aws.FIPSEndpointStateEnabled))
+	}
+
+	hc := awshttp.NewBuildableClient()
+	if httpOpts.Timeout > 0 {
+		hc = hc.WithTimeout(httpOpts.Timeout)
+	}
+	hc = hc.WithTransportOptions(func(tr *http.Transport) {
+		if httpOpts.MaxIdleConnsPerHost > 0 {
+			tr.MaxIdleConnsPerHost = httpOpts.MaxIdleConnsPerHost
+			if tr.MaxIdleConns < httpOpts.MaxIdleConnsPerHost {
+				tr.MaxIdleConns = httpOpts.MaxIdleConnsPerHost
+			}
+		}
+		if httpOpts.ProxyURL != "" {
+			if u, err := url.Parse(httpOpts.ProxyURL); err == nil {
+				tr.Proxy = http.ProxyURL(u)
+			}
+		}
+	})
+	opts = append(opts, awsconfig.WithHTTPClient(hc))
+
+	if httpOpts.CABundle != "" {
+		pem, err := os.Open(httpOpts.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("CA bundle: %w", err)
+		}
+		defer pem.Close()
+		opts = append(opts, awsconfig.WithCustomCABundle(pem))
+	}
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	if role != nil && role.RoleARN != "" {
+		cfg.Credentials = role.credentials(cfg)
+	}
+	if modelID == "" {
+		modelID = DefaultModelID
+	}
+	modelID = profileARN(modelID)
+	if err := ValidateModelForRegion(cfg.Region, modelID); err != nil {
+		return nil, err
+	}
+
+	endpoint := httpOpts.EndpointURL
+	if endpoint == "" {
+		endpoint = os.Getenv("CLAUDE_CLI_BEDROCK_ENDPOINT")
+	}
+	var rtOpts []func(*bedrockruntime.Options)
+	if endpoint != "" {
+		rtOpts = append(rtOpts, func(o *bedrockruntime.Options) {
+			o.BaseEndpoint = aws.String(endpoint)
+		})
+	}
+
+	// A Bedrock API key replaces SigV4: requests carry the bearer token and
+	// the credential chain is never consulted. See apikey.go.
+	apiKey, err := bedrockAPIKey(ctx, cfg.Region)
+	if err != nil {
+		return nil, err
+	}
+	if apiKey != "" {
+		cfg.Credentials = aws.AnonymousCredentials{}
+		rtOpts = append(rtOpts, withBearerAuth(apiKey))
+	}
+	return &Client{
+		rt:      bedrockruntime.NewFromConfig(cfg, rtOpts...),
+		ModelID: modelID,
+		last:    &lastState{},
+	}, nil
+}
+
+// WithModel returns a client targeting modelID while sharing the underlying
+// Bedrock client and its connection pool.
+func (c *Client) WithModel(modelID string) *Client {
+	cc := *c
+	cc.ModelID = modelID
+	return &cc
+}
+
+// modelFor resolves the model for a request, honoring a per-request
+// override.
+func (c *Client) modelFor(req Request) string {
+	if req.Model != "" {
+		return req.Model
+	}
+	return c.ModelID
+}
+
+// Message aliases the Converse message type so callers do not need to import
+// the Bedrock Runtime types package directly.
+type Message = types.Message
+
+// Request describes a single Converse invocation.
+type Request struct {
+	// Model overrides the client's model for this request only; empty
+	// uses Client.ModelID.
+	Model       string
+	System      string
+	Messages    []types.Message
+	MaxTokens   int32
+	Temperature *float32
+	// ThinkingBudget enables extended thinking with the given token
+	// budget on models that support it; it passes through
+	// additionalModelRequestFields.
+	ThinkingBudget int32
+	// Seed requests deterministic sampling on models that support it; it is
+	// passed through additionalModelRequestFields and ignored by models that
+	// do not.
+	Seed *int64
+	// Tools advertises tools the model may call; responses must then be
+	// driven through ConverseTurn, which surfaces tool-use stops.
+	Tools []types.Tool
+	// Betas names Anthropic beta features (e.g. computer use) to enable via
+	// the anthropic_beta passthrough field.
+	Betas []string
+	// AnthropicTools carries Anthropic-defined tool declarations (computer
+	// use and similar betas) that have no Converse toolSpec form; they pass
+	// through additionalModelRequestFields.
+	AnthropicTools []map[string]any
+	// Metadata is attached as requestMetadata, surfacing in Bedrock
+	// invocation logs for cost allocation; models never see it.
+	Metadata map[string]string
+	// GuardrailID attaches a guardrail to the invocation; GuardrailVersion
+	// defaults to the draft when empty.
+	GuardrailID      string
+	GuardrailVersion string
+	// Latency selects the inference tier via performanceConfig:
+	// "optimized" requests latency-optimized inference, "" or "standard"
+	// keeps the default. Unsupported model/region combinations fall back
+	// to standard with a warning.
+	Latency string
+	// ServiceTier selects priority processing where the model offers it:
+	// "priority", "standard", or "flex". Empty keeps the account default;
+	// it passes through additionalModelRequestFields, so models without
+	// tiers reject unknown values with a validation error.
+	ServiceTier string
+}
+
+func (req Request) additionalFieldsFor(modelID string) document.Interface {
+	// The passthrough fields below are Anthropic-specific; other Converse
+	// model families reject them as unknown keys. Validation has already
+	// failed requests that relied on them.
+	if !IsAnthropicModel(modelID) {
+		return nil
+	}
+	fields := map[string]any{}
+	if req.Seed != nil {
+		fields["seed"] = *req.Seed
+	}
+	if len(req.Betas) > 0 {
+		fields["anthropic_beta"] = req.Betas
+	}
+	if len(req.AnthropicTools) > 0 {
+		fields["tools"] = req.AnthropicTools
+	}
+	if req.ServiceTier != "" {
+		fields["service_tier"] = req.ServiceTier
+	}
+	if req.ThinkingBudget > 0 {
+		fields["thinking"] = map[string]any{
+			"type":          "enabled",
+			"budget_tokens": req.ThinkingBudget,
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return document.NewLazyDocument(fields)
+}
+
+// UserMessage builds a single-text-block user message.
+func UserMessage(text string) types.Message {
+	return types.Message{
+		Role: types.ConversationRoleUser,
+		Content: []types.ContentBlock{
+			&types.ContentBlockMemberText{Value: text},
+		},
+	}
+}
+
+// Image is an inline image attachment for a multimodal message.
+type Image struct {
+	Format string // "png", "jpeg", "gif", or "webp"
+	Data   []byte
+}
+
+// UserMessageWithImages builds a user message carrying image blocks followed
+// by a text block.
+func UserMessageWithImages(text string, images []Image) types.Message {
+	var blocks []types.ContentBlock
+	for _, img := range images {
+		blocks = append(blocks, &types.ContentBlockMemberImage{
+			Value: types.ImageBlock{
+				Format: types.ImageFormat(img.Format),
+				Source: &types.ImageSourceMemberBytes{Value: img.Data},
+			},
+		})
+	}
+	blocks = append(blocks, &types.ContentBlockMemberText{Value: text})
+	return types.Message{Role: types.ConversationRoleUser, Content: blocks}
+}
+
+// AssistantMessage builds a single-text-block assistant message.
+func AssistantMessage(text string) types.Message {
+	return types.Message{
+		Role: types.ConversationRoleAssistant,
+		Content: []types.ContentBlock{
+			&types.ContentBlockMemberText{Value: text},
+		},
+	}
+}
+
+func (c *Client) inferenceConfig(req Request) *types.InferenceConfiguration {
+	cfg := &types.InferenceConfiguration{}
+	if req.MaxTokens > 0 {
+		cfg.MaxTokens = aws.Int32(req.MaxTokens)
+	}
+	if req.Temperature != nil {
+		cfg.Temperature = req.Temperature
+	}
+	return cfg
+}
+
+func (c *Client) toolConfig(req Request) *types.ToolConfiguration {
+	if len(req.Tools) == 0 {
+		return nil
+	}
+	return &types.ToolConfiguration{Tools: req.Tools}
+}
+
+func (c *Client) performanceConfig(req Request) *types.PerformanceConfiguration {
+	if req.Latency == "" || req.Latency == "standard" {
+		return nil
+	}
+	return &types.PerformanceConfiguration{Latency: types.PerformanceConfigLatency(req.Latency)}
+}
+
+// unsupportedPerformanceConfig detects the validation error Bedrock
+// returns when a model/region combination has no latency-optimized tier.
+func unsupportedPerformanceConfig(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) &&
+		apiErr.ErrorCode() == "ValidationException" &&
+		strings.Contains(strings.ToLower(apiErr.ErrorMessage()), "performance")
+}
+
+func (c *Client) guardrailConfig(req Request) *types.GuardrailConfiguration {
+	if req.GuardrailID == "" {
+		return nil
+	}
+	version := req.GuardrailVersion
+	if version == "" {
+		version = "DRAFT"
+	}
+	return &types.GuardrailConfiguration{
+		GuardrailIdentifier: aws.String(req.GuardrailID),
+		GuardrailVersion:    aws.String(version),
+	}
+}
+
+func (c *Client) guardrailStreamConfig(req Request) *types.GuardrailStreamConfiguration {
+	cfg := c.guardrailConfig(req)
+	if cfg == nil {
+		return nil
+	}
+	return &types.GuardrailStreamConfiguration{
+		GuardrailIdentifier: cfg.GuardrailIdentifier,
+		GuardrailVersion:    cfg.GuardrailVersion,
+	}
+}
+
+func (c *Client) systemBlocks(req Request) []types.SystemContentBlock {
+	if req.System == "" {
+		return nil
+	}
+	return []types.SystemContentBlock{
+		&types.SystemContentBlockMemberText{Value: req.System},
+	}
+}
+
+// Usage reports token consumption for one invocation.
+type Usage struct {
+	InputTokens  int32
+	OutputTokens int32
+}
+
+// Add accumulates another invocation's usage into u.
+func (u *Usage) Add(other Usage) {
+	u.InputTokens += other.InputTokens
+	u.OutputTokens += other.OutputTokens
+}
+
+func tokenUsage(tu *types.TokenUsage) Usage {
+	if tu == nil {
+		return Usage{}
+	}
+	var u Usage
+	if tu.InputTokens != nil {
+		u.InputTokens = *tu.InputTokens
+	}
+	if tu.OutputTokens != nil {
+		u.OutputTokens = *tu.OutputTokens
+	}
+	return u
+}
+
+// Converse sends the request and returns the assistant's text response.
+func (c *Client) Converse(ctx context.Context, req Request) (string, error) {
+	text, _, err := c.ConverseUsage(ctx, req)
+	return text, err
+}
+
+// ConverseUsage is Converse, additionally reporting token usage.
+func (c *Client) ConverseUsage(ctx context.Context, req Request) (string, Usage, error) {
+	msg, _, usage, err := c.ConverseTurn(ctx, req)
+	if err != nil {
+		return "", usage, err
+	}
+	var sb strings.Builder
+	for _, block := range msg.Content {
+		if text, ok := block.(*types.ContentBlockMemberText); ok {
+			sb.WriteString(text.Value)
+		}
+	}
+	return sb.String(), usage, nil
+}
+
+// ConverseTurn sends the request and returns the full assistant message with
+// its stop reason, which agent loops need to detect and answer tool calls.
+func (c *Client) ConverseTurn(ctx context.Context, req Request) (*types.Message, types.StopReason, Usage, error) {
+	model := c.modelFor(req)
+	if err := ValidateRequest(model, req); err != nil {
+		return nil, "", Usage{}, fmt.Errorf("converse: %w", err)
+	}
+	c.last.setStopReason("")
+	out, err := c.rt.Converse(ctx, &bedrockruntime.ConverseInput{
+		ModelId:                      aws.String(model),
+		Messages:                     req.Messages,
+		System:                       c.systemBlocks(req),
+		InferenceConfig:              c.inferenceConfig(req),
+		ToolConfig:                   c.toolConfig(req),
+		GuardrailConfig:              c.guardrailConfig(req),
+		PerformanceConfig:            c.performanceConfig(req),
+		AdditionalModelRequestFields: req.additionalFieldsFor(model),
+		RequestMetadata:              req.Metadata,
+	})
+	if err != nil {
+		if c.performanceConfig(req) != nil && unsupportedPerformanceConfig(err) {
+			fmt.Fprintf(os.Stderr, "warning: %s does not support latency-optimized inference here; falling back to standard\n", c.ModelID)
+			req.Latency = ""
+			return c.ConverseTurn(ctx, req)
+		}
+		return nil, "", Usage{}, fmt.Errorf("converse: %w", err)
+	}
+	if id, ok := awsmiddleware.GetRequestIDMetadata(out.ResultMetadata); ok {
+		c.last.setRequestID(id)
+	}
+	c.last.setStopReason(out.StopReason)
+	msg, ok := out.Output.(*types.ConverseOutputMemberMessage)
+	if !ok {
+		return nil, "", Usage{}, fmt.Errorf("converse: unexpected output type %T", out.Output)
+	}
+	return &msg.Value, out.StopReason, tokenUsage(out.Usage), nil
+}
+
+// ConverseUntilEnd is ConverseUsage with automatic continuation: when
+// generation stops at the max_tokens limit, the output so far is fed back as
+// assistant prefill and the request reissued until the model reaches a
+// natural end_turn (or maxSegments requests have been made). The returned
+// usage sums all segments.
+func (c *Client) ConverseUntilEnd(ctx context.Context, req Request, maxSegments int) (string, Usage, error) {
+	if maxSegments <= 0 {
+		maxSegments = 4
+	}
+	base := req.Messages
+	var sb strings.Builder
+	var total Usage
+	for segment := 0; segment < maxSegments; segment++ {
+		if sb.Len() > 0 {
+			// Prefill: the partial output continues mid-token-stream.
+			req.Messages = append(append([]types.Message(nil), base...), AssistantMessage(sb.String()))
+		}
+		msg, stopReason, usage, err := c.ConverseTurn(ctx, req)
+		if err != nil {
+			return sb.String(), total, err
+		}
+		total.Add(usage)
+		for _, block := range msg.Content {
+			if text, ok := block.(*types.ContentBlockMemberText); ok {
+				sb.WriteString(text.Value)
+			}
+		}
+		if stopReason != types.StopReasonMaxTokens {
+			return sb.String(), total, nil
+		}
+	}
+	return sb.String(), total, fmt.Errorf("converse: output still truncated after %d segments", maxSegments)
+}
+
+// ConverseStream sends the request, calls onDelta for every text delta as it
+// arrives, and returns the full assembled response.
+func (c *Client) ConverseStream(ctx context.Context, req Request, onDelta func(string)) (string, error) {
+	text, _, err := c.ConverseStreamUsage(ctx, req, onDelta)
+	return text, err
+}
+
+// ConverseStreamUsage is ConverseStream, additionally reporting the token
+// usage carried in the stream's metadata event.
+func (c *Client) ConverseStreamUsage(ctx context.Context, req Request, onDelta func(string)) (string, Usage, error) {
+	model := c.modelFor(req)
+	if err := ValidateRequest(model, req); err != nil {
+		return "", Usage{}, fmt.Errorf("converse stream: %w", err)
+	}
+	if cap, ok := LookupCapability(model); ok && !cap.Streaming {
+		return "", Usage{}, fmt.Errorf("converse stream: %s does not support streaming", model)
+	}
+	c.last.setStopReason("")
+	out, err := c.rt.ConverseStream(ctx, &bedrockruntime.ConverseStreamInput{
+		ModelId:                      aws.String(model),
+		Messages:                     req.Messages,
+		System:                       c.systemBlocks(req),
+		InferenceConfig:              c.inferenceConfig(req),
+		ToolConfig:                   c.toolConfig(req),
+		GuardrailConfig:              c.guardrailStreamConfig(req),
+		PerformanceConfig:            c.performanceConfig(req),
+		AdditionalModelRequestFields: req.additionalFieldsFor(model),
+		RequestMetadata:              req.Metadata,
+	})
+	if err != nil {
+		if c.performanceConfig(req) != nil && unsupportedPerformanceConfig(err) {
+			fmt.Fprintf(os.Stderr, "warning: %s does not support latency-optimized inference here; falling back to standard\n", c.ModelID)
+			req.Latency = ""
+			return c.ConverseStreamUsage(ctx, req, onDelta)
+		}
+		return "", Usage{}, fmt.Errorf("converse stream: %w", err)
+	}
+	if id, ok := awsmiddleware.GetRequestIDMetadata(out.ResultMetadata); ok {
+		c.last.setRequestID(id)
+	}
+	stream := out.GetStream()
+	defer stream.Close()
+
+	var sb strings.Builder
+	var usage Usage
+	for event := range stream.Events() {
+		switch ev := event.(type) {
+		case *types.ConverseStreamOutputMemberContentBlockDelta:
+			if delta, ok := ev.Value.Delta.(*types.ContentBlockDeltaMemberText); ok {
+				sb.WriteString(delta.Value)
+				if onDelta != nil {
+					onDelta(delta.Value)
+				}
+			}
+		case *types.ConverseStreamOutputMemberMessageStop:
+			c.last.setStopReason(ev.Value.StopReason)
+		case *types.ConverseStreamOutputMemberMetadata:
+			usage = tokenUsage(ev.Value.Usage)
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return "", usage, fmt.Errorf("converse stream: %w", err)
+	}
+	return sb.String(), usage, nil
+}
@@ -0,0 +1,82 @@
+package claude
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// Coalescer batches tiny streaming text deltas into larger flushes, cutting
+// syscall and frame overhead when the sink is slow — a terminal over SSH, or
+// a per-message transport like Server-Sent Events or WebSockets. Deltas
+// accumulate until maxBytes are buffered or maxDelay has passed since the
+// first unflushed byte, whichever comes first; Close flushes the remainder.
+// It is safe for concurrent use.
+type Coalescer struct {
+	flush    func(string)
+	maxBytes int
+	maxDelay time.Duration
+
+	mu    sync.Mutex
+	buf   strings.Builder
+	timer *time.Timer
+}
+
+// Coalescing defaults: flush at 512 buffered bytes or after 50ms, a window
+// small enough to stay imperceptible on an interactive stream.
+const (
+	defaultCoalesceBytes = 512
+	DefaultCoalesceDelay = 50 * time.Millisecond
+)
+
+// NewCoalescer returns a Coalescer delivering batched text through flush.
+// maxBytes <= 0 and maxDelay <= 0 apply the defaults.
+func NewCoalescer(flush func(string), maxBytes int, maxDelay time.Duration) *Coalescer {
+	if maxBytes <= 0 {
+		maxBytes = defaultCoalesceBytes
+	}
+	if maxDelay <= 0 {
+		maxDelay = DefaultCoalesceDelay
+	}
+	return &Coalescer{flush: flush, maxBytes: maxBytes, maxDelay: maxDelay}
+}
+
+// Add buffers one delta, flushing if the byte threshold is reached and
+// arming the delay timer otherwise.
+func (co *Coalescer) Add(delta string) {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+	co.buf.WriteString(delta)
+	if co.buf.Len() >= co.maxBytes {
+		co.flushLocked()
+		return
+	}
+	if co.timer == nil {
+		co.timer = time.AfterFunc(co.maxDelay, func() {
+			co.mu.Lock()
+			defer co.mu.Unlock()
+			co.timer = nil
+			co.flushLocked()
+		})
+	}
+}
+
+// Close flushes whatever is still buffered; call it once the stream ends.
+func (co *Coalescer) Close() {
+	co.mu.Lock()
+	defer co.mu.Unlock()
+	co.flushLocked()
+}
+
+func (co *Coalescer) flushLocked() {
+	if co.timer != nil {
+		co.timer.Stop()
+		co.timer = nil
+	}
+	if co.buf.Len() == 0 {
+		return
+	}
+	text := co.buf.String()
+	co.buf.Reset()
+	co.flush(text)
+}
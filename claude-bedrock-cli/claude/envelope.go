@@ -0,0 +1,70 @@
+package claude
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+)
+
+// Envelope wraps a model response with everything needed to reproduce and
+// audit the invocation.
+type Envelope struct {
+	Text string `json:"text"`
+
+	Model        string   `json:"model"`
+	MaxTokens    int32    `json:"max_tokens,omitempty"`
+	Temperature  *float32 `json:"temperature,omitempty"`
+	Seed         *int64   `json:"seed,omitempty"`
+	Betas        []string `json:"betas,omitempty"`
+	PromptSHA256 string   `json:"prompt_sha256"`
+
+	InputTokens  int32     `json:"input_tokens"`
+	OutputTokens int32     `json:"output_tokens"`
+	Timestamp    time.Time `json:"timestamp"`
+
+	// StopReason records why generation ended; GuardrailIntervened flags
+	// that a guardrail cut it short, in which case Text may be partial.
+	StopReason          string `json:"stop_reason,omitempty"`
+	GuardrailIntervened bool   `json:"guardrail_intervened,omitempty"`
+
+	// Claims holds the results of an optional claim-verification pass; an
+	// entry with Supported false marks a claim the source did not back.
+	Claims []ClaimCheck `json:"claims,omitempty"`
+}
+
+// PromptHash returns the SHA-256 of the request's system prompt and message
+// text content, stable across runs with identical prompts.
+func PromptHash(req Request) string {
+	h := sha256.New()
+	h.Write([]byte(req.System))
+	for _, msg := range req.Messages {
+		h.Write([]byte{0})
+		h.Write([]byte(msg.Role))
+		for _, block := range msg.Content {
+			if text, ok := block.(*types.ContentBlockMemberText); ok {
+				h.Write([]byte{0})
+				h.Write([]byte(text.Value))
+			}
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// NewEnvelope assembles the reproducibility envelope for a completed
+// invocation.
+func NewEnvelope(modelID string, req Request, text string, usage Usage) *Envelope {
+	return &Envelope{
+		Text:         text,
+		Model:        modelID,
+		MaxTokens:    req.MaxTokens,
+		Temperature:  req.Temperature,
+		Seed:         req.Seed,
+		Betas:        req.Betas,
+		PromptSHA256: PromptHash(req),
+		InputTokens:  usage.InputTokens,
+		OutputTokens: usage.OutputTokens,
+		Timestamp:    time.Now().UTC(),
+	}
+}
@@ -0,0 +1,56 @@
+package claude
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+)
+
+// GuardrailResult is the structured outcome of a standalone guardrail
+// check.
+type GuardrailResult struct {
+	// Intervened reports whether the guardrail blocked or masked content.
+	Intervened bool `json:"intervened"`
+	// OutputText is the guardrail's replacement output (the configured
+	// blocked message or the input with sensitive spans masked); empty
+	// when the guardrail passed the content through.
+	OutputText string `json:"output_text,omitempty"`
+	// Assessments carries the per-policy findings exactly as the API
+	// returns them: topic, content-filter, word, sensitive-information,
+	// and contextual-grounding results.
+	Assessments []types.GuardrailAssessment `json:"assessments"`
+}
+
+// ApplyGuardrail validates text against a guardrail without invoking a
+// model, for checking user input before spending tokens on it or auditing
+// output produced elsewhere. source is "INPUT" or "OUTPUT" and selects
+// which side's policies apply.
+func (c *Client) ApplyGuardrail(ctx context.Context, guardrailID, version, source, text string) (*GuardrailResult, error) {
+	if version == "" {
+		version = "DRAFT"
+	}
+	out, err := c.rt.ApplyGuardrail(ctx, &bedrockruntime.ApplyGuardrailInput{
+		GuardrailIdentifier: aws.String(guardrailID),
+		GuardrailVersion:    aws.String(version),
+		Source:              types.GuardrailContentSource(source),
+		Content: []types.GuardrailContentBlock{
+			&types.GuardrailContentBlockMemberText{
+				Value: types.GuardrailTextBlock{Text: aws.String(text)},
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("apply guardrail %s: %w", guardrailID, err)
+	}
+	result := &GuardrailResult{
+		Intervened:  out.Action == types.GuardrailActionGuardrailIntervened,
+		Assessments: out.Assessments,
+	}
+	for _, output := range out.Outputs {
+		result.OutputText += aws.ToString(output.Text)
+	}
+	return result, nil
+}
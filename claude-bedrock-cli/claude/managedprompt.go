@@ -0,0 +1,91 @@
+package claude
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockagent"
+	agenttypes "github.com/aws/aws-sdk-go-v2/service/bedrockagent/types"
+)
+
+// ManagedPrompt is a prompt template fetched from Bedrock Prompt
+// Management, so prompt text and versioning are governed in AWS rather
+// than local files.
+type ManagedPrompt struct {
+	Name    string
+	Variant string
+	// ModelID is the model the variant was authored for; empty when the
+	// prompt does not pin one.
+	ModelID string
+	// Text is the raw template, with {{variable}} placeholders.
+	Text string
+	// Variables lists the input variables the template declares.
+	Variables []string
+}
+
+// GetManagedPrompt fetches a prompt by ARN, ID, or name via GetPrompt.
+// version selects a numbered prompt version; empty means the draft. The
+// prompt's default variant is returned, or the named variant when the
+// identifier has a "#variant" suffix.
+func GetManagedPrompt(ctx context.Context, region, identifier, version string) (*ManagedPrompt, error) {
+	identifier, wantVariant, _ := strings.Cut(identifier, "#")
+	var opts []func(*awsconfig.LoadOptions) error
+	if region != "" {
+		opts = append(opts, awsconfig.WithRegion(region))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	in := &bedrockagent.GetPromptInput{PromptIdentifier: aws.String(identifier)}
+	if version != "" {
+		in.PromptVersion = aws.String(version)
+	}
+	out, err := bedrockagent.NewFromConfig(cfg).GetPrompt(ctx, in)
+	if err != nil {
+		return nil, fmt.Errorf("fetching managed prompt %s: %w", identifier, err)
+	}
+	if wantVariant == "" {
+		wantVariant = aws.ToString(out.DefaultVariant)
+	}
+	for _, variant := range out.Variants {
+		name := aws.ToString(variant.Name)
+		if wantVariant != "" && name != wantVariant {
+			continue
+		}
+		text, ok := variant.TemplateConfiguration.(*agenttypes.PromptTemplateConfigurationMemberText)
+		if !ok {
+			return nil, fmt.Errorf("managed prompt %s variant %s is not a text template", identifier, name)
+		}
+		mp := &ManagedPrompt{
+			Name:    aws.ToString(out.Name),
+			Variant: name,
+			ModelID: aws.ToString(variant.ModelId),
+			Text:    aws.ToString(text.Value.Text),
+		}
+		for _, v := range text.Value.InputVariables {
+			mp.Variables = append(mp.Variables, aws.ToString(v.Name))
+		}
+		return mp, nil
+	}
+	return nil, fmt.Errorf("managed prompt %s has no variant %q", identifier, wantVariant)
+}
+
+// Render substitutes {{variable}} placeholders and errors on any declared
+// variable left without a value, since a half-filled template silently
+// changes the prompt's meaning.
+func (p *ManagedPrompt) Render(vars map[string]string) (string, error) {
+	text := p.Text
+	for key, val := range vars {
+		text = strings.ReplaceAll(text, "{{"+key+"}}", val)
+	}
+	for _, name := range p.Variables {
+		if strings.Contains(text, "{{"+name+"}}") {
+			return "", fmt.Errorf("managed prompt %s: variable %q not provided", p.Name, name)
+		}
+	}
+	return text, nil
+}
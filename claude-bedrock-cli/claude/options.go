@@ -0,0 +1,166 @@
+package claude
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+)
+
+// Option configures a Request assembled by NewRequest.
+type Option func(*requestBuilder)
+
+type requestBuilder struct {
+	req    Request
+	docs   []string
+	prompt string
+}
+
+// WithModel targets a specific model, overriding the client's default.
+func WithModel(modelID string) Option {
+	return func(b *requestBuilder) { b.req.Model = modelID }
+}
+
+// WithSystem sets the system prompt.
+func WithSystem(system string) Option {
+	return func(b *requestBuilder) { b.req.System = system }
+}
+
+// WithPrompt sets the user prompt text.
+func WithPrompt(text string) Option {
+	return func(b *requestBuilder) { b.prompt = text }
+}
+
+// WithDocument attaches a named context document; documents precede the
+// prompt in a single user message so message roles still alternate.
+func WithDocument(name, text string) Option {
+	return func(b *requestBuilder) {
+		b.docs = append(b.docs, fmt.Sprintf("<document name=%q>\n%s\n</document>", name, text))
+	}
+}
+
+// WithMessages uses a pre-built conversation instead of WithPrompt.
+func WithMessages(messages ...types.Message) Option {
+	return func(b *requestBuilder) { b.req.Messages = messages }
+}
+
+// WithMaxTokens caps the output length.
+func WithMaxTokens(n int32) Option {
+	return func(b *requestBuilder) { b.req.MaxTokens = n }
+}
+
+// WithTemperature sets the sampling temperature.
+func WithTemperature(t float32) Option {
+	return func(b *requestBuilder) { b.req.Temperature = aws.Float32(t) }
+}
+
+// WithThinking enables extended thinking with the given token budget.
+func WithThinking(budget int32) Option {
+	return func(b *requestBuilder) { b.req.ThinkingBudget = budget }
+}
+
+// WithBetas enables Anthropic beta features.
+func WithBetas(betas ...string) Option {
+	return func(b *requestBuilder) { b.req.Betas = betas }
+}
+
+// WithGuardrail attaches a guardrail; version "" uses the draft.
+func WithGuardrail(id, version string) Option {
+	return func(b *requestBuilder) {
+		b.req.GuardrailID, b.req.GuardrailVersion = id, version
+	}
+}
+
+// NewRequest assembles a Request from options and validates the
+// combination, so invalid requests fail at construction with a clear
+// message rather than at the service.
+func NewRequest(opts ...Option) (Request, error) {
+	b := &requestBuilder{}
+	for _, opt := range opts {
+		opt(b)
+	}
+	req := b.req
+	if len(b.docs) > 0 || b.prompt != "" {
+		if len(req.Messages) > 0 {
+			return Request{}, fmt.Errorf("request: use WithPrompt/WithDocument or WithMessages, not both")
+		}
+		var sb strings.Builder
+		for _, doc := range b.docs {
+			sb.WriteString(doc + "\n")
+		}
+		sb.WriteString(b.prompt)
+		req.Messages = []types.Message{UserMessage(sb.String())}
+	}
+	if len(req.Messages) == 0 {
+		return Request{}, fmt.Errorf("request: a prompt or messages are required")
+	}
+	if req.ThinkingBudget > 0 {
+		if req.Temperature != nil {
+			return Request{}, fmt.Errorf("request: extended thinking requires the default temperature")
+		}
+		if req.MaxTokens > 0 && req.ThinkingBudget >= req.MaxTokens {
+			return Request{}, fmt.Errorf("request: thinking budget %d must be below max tokens %d", req.ThinkingBudget, req.MaxTokens)
+		}
+		if cap, ok := LookupCapability(req.Model); req.Model != "" && ok && !cap.Thinking {
+			return Request{}, fmt.Errorf("request: %s does not support extended thinking", req.Model)
+		}
+	}
+	if req.Model != "" {
+		if err := ValidateRequest(req.Model, req); err != nil {
+			return Request{}, fmt.Errorf("request: %w", err)
+		}
+	}
+	return req, nil
+}
+
+// AnthropicPayload renders the request as a native Anthropic Messages
+// payload for InvokeModel, for features the Converse API does not expose.
+func (req Request) AnthropicPayload() ([]byte, error) {
+	body := map[string]any{
+		"anthropic_version": "bedrock-2023-05-31",
+		"max_tokens":        req.MaxTokens,
+	}
+	if req.System != "" {
+		body["system"] = req.System
+	}
+	if req.Temperature != nil {
+		body["temperature"] = *req.Temperature
+	}
+	if req.ThinkingBudget > 0 {
+		body["thinking"] = map[string]any{"type": "enabled", "budget_tokens": req.ThinkingBudget}
+	}
+	if len(req.Betas) > 0 {
+		body["anthropic_beta"] = req.Betas
+	}
+	var messages []map[string]any
+	for _, msg := range req.Messages {
+		var content []map[string]any
+		for _, block := range msg.Content {
+			switch block := block.(type) {
+			case *types.ContentBlockMemberText:
+				content = append(content, map[string]any{"type": "text", "text": block.Value})
+			case *types.ContentBlockMemberImage:
+				src, ok := block.Value.Source.(*types.ImageSourceMemberBytes)
+				if !ok {
+					return nil, fmt.Errorf("request: unsupported image source %T", block.Value.Source)
+				}
+				content = append(content, map[string]any{
+					"type": "image",
+					"source": map[string]any{
+						"type":       "base64",
+						"media_type": "image/" + string(block.Value.Format),
+						"data":       base64.StdEncoding.EncodeToString(src.Value),
+					},
+				})
+			default:
+				return nil, fmt.Errorf("request: content block %T has no native payload form", block)
+			}
+		}
+		messages = append(messages, map[string]any{"role": string(msg.Role), "content": content})
+	}
+	body["messages"] = messages
+	return json.Marshal(body)
+}
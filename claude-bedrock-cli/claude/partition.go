@@ -0,0 +1,48 @@
+package claude
+
+import (
+	"fmt"
+	"strings"
+)
+
+// partition names follow the AWS SDK: commercial, GovCloud, and China.
+func partition(region string) string {
+	switch {
+	case strings.HasPrefix(region, "us-gov-"):
+		return "aws-us-gov"
+	case strings.HasPrefix(region, "cn-"):
+		return "aws-cn"
+	default:
+		return "aws"
+	}
+}
+
+// govCloudModelPrefixes lists the Claude model families available in the
+// GovCloud partition. The list is best-effort — availability changes — so it
+// gates on model family rather than exact versions; a live preflight against
+// the control plane remains the authoritative check.
+var govCloudModelPrefixes = []string{
+	"anthropic.claude-3-5-sonnet",
+	"anthropic.claude-3-haiku",
+}
+
+// ValidateModelForRegion reports a capability error when the model is known
+// to be absent from the region's partition, so users get a clear message
+// instead of an opaque endpoint error. Commercial regions are not gated.
+func ValidateModelForRegion(region, modelID string) error {
+	switch partition(region) {
+	case "aws-cn":
+		if strings.Contains(modelID, "anthropic.") {
+			return fmt.Errorf("model %s is not available in the China partition (region %s)", modelID, region)
+		}
+	case "aws-us-gov":
+		for _, prefix := range govCloudModelPrefixes {
+			if strings.Contains(modelID, prefix) {
+				return nil
+			}
+		}
+		return fmt.Errorf("model %s is not available in GovCloud (region %s); available families: %s",
+			modelID, region, strings.Join(govCloudModelPrefixes, ", "))
+	}
+	return nil
+}
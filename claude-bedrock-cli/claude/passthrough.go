@@ -0,0 +1,40 @@
+package claude
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The Converse surface this package wraps is model-agnostic, so Nova, Llama,
+// Mistral, and the rest of the Converse-capable catalog invoke and stream
+// through the same Client. What does differ is the Anthropic passthrough
+// carried in additionalModelRequestFields: other families reject those keys
+// as unknown, so requests using them fail locally with a clear message and
+// the passthrough is omitted entirely for non-Anthropic models.
+
+// IsAnthropicModel reports whether the model ID names a Claude model,
+// including cross-region profile and ARN forms.
+func IsAnthropicModel(modelID string) bool {
+	return strings.Contains(modelID, "anthropic") || strings.Contains(modelID, "claude")
+}
+
+// validateAnthropicFields rejects Anthropic-only request features when the
+// target model is from another family.
+func validateAnthropicFields(modelID string, req Request) error {
+	if IsAnthropicModel(modelID) {
+		return nil
+	}
+	switch {
+	case len(req.Betas) > 0:
+		return fmt.Errorf("%s is not an Anthropic model and does not accept anthropic_beta features", modelID)
+	case req.ThinkingBudget > 0:
+		return fmt.Errorf("%s is not an Anthropic model and does not support extended thinking", modelID)
+	case len(req.AnthropicTools) > 0:
+		return fmt.Errorf("%s is not an Anthropic model and does not accept Anthropic-defined tools", modelID)
+	case req.Seed != nil:
+		return fmt.Errorf("%s does not accept the seed passthrough field", modelID)
+	case req.ServiceTier != "":
+		return fmt.Errorf("%s does not accept the service_tier passthrough field", modelID)
+	}
+	return nil
+}
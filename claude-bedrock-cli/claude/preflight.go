@@ -0,0 +1,96 @@
+package claude
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/bedrock"
+	bedrocktypes "github.com/aws/aws-sdk-go-v2/service/bedrock/types"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/smithy-go"
+)
+
+// Preflight asks the Bedrock control plane whether modelID can actually be
+// invoked in region before any real request is sent: the model must exist
+// there, must not be deprecated, and the account must have been granted
+// access. Failures come back with a concrete remediation instead of the
+// generic validation error an invocation would produce.
+func Preflight(ctx context.Context, region, modelID string) error {
+	var opts []func(*awsconfig.LoadOptions) error
+	if region != "" {
+		opts = append(opts, awsconfig.WithRegion(region))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return err
+	}
+
+	// Model ARNs name provisioned or imported resources the foundation
+	// model listing does not cover; only the access probe applies.
+	if !strings.HasPrefix(modelID, "arn:") {
+		if err := checkModelListed(ctx, bedrock.NewFromConfig(cfg), cfg.Region, modelID); err != nil {
+			return err
+		}
+	}
+	return checkModelAccess(ctx, bedrockruntime.NewFromConfig(cfg), cfg.Region, modelID)
+}
+
+// checkModelListed verifies existence and lifecycle against the
+// foundation-model catalog.
+func checkModelListed(ctx context.Context, cp *bedrock.Client, region, modelID string) error {
+	out, err := cp.ListFoundationModels(ctx, &bedrock.ListFoundationModelsInput{})
+	if err != nil {
+		return fmt.Errorf("preflight: listing models in %s: %w", region, err)
+	}
+	base := baseModelID(modelID)
+	for _, m := range out.ModelSummaries {
+		if aws.ToString(m.ModelId) != base {
+			continue
+		}
+		if m.ModelLifecycle != nil && m.ModelLifecycle.Status != bedrocktypes.FoundationModelLifecycleStatusActive {
+			return fmt.Errorf("preflight: model %s is %s in %s; migrate to a newer model version",
+				modelID, m.ModelLifecycle.Status, region)
+		}
+		return nil
+	}
+	return fmt.Errorf("preflight: model %q does not exist in %s; check the model ID or choose another region", modelID, region)
+}
+
+// checkModelAccess sends a one-token probe and translates an access
+// denial into its remediation. Throttling means the model is reachable,
+// so it passes.
+func checkModelAccess(ctx context.Context, rt *bedrockruntime.Client, region, modelID string) error {
+	probe := &Client{rt: rt, ModelID: modelID}
+	_, err := probe.Converse(ctx, Request{
+		Messages:  []Message{UserMessage("ping")},
+		MaxTokens: 1,
+	})
+	if err == nil {
+		return nil
+	}
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "AccessDeniedException":
+			return fmt.Errorf("preflight: the account has no access to %s in %s; enable it on the Bedrock console's Model access page and retry", modelID, region)
+		case "ThrottlingException":
+			return nil
+		}
+	}
+	return fmt.Errorf("preflight: %s in %s: %w", modelID, region, err)
+}
+
+// baseModelID strips a cross-region inference profile prefix (us., eu.,
+// apac.) so the catalog lookup matches the underlying foundation model.
+func baseModelID(modelID string) string {
+	for _, prefix := range []string{"us.", "eu.", "apac."} {
+		if strings.HasPrefix(modelID, prefix) {
+			return modelID[len(prefix):]
+		}
+	}
+	return modelID
+}
@@ -0,0 +1,65 @@
+package claude
+
+import "strings"
+
+// modelPricing holds on-demand USD prices per 1,000 tokens. Keys are model ID
+// substrings so cross-region inference profile prefixes still match.
+var modelPricing = map[string]struct{ in, out float64 }{
+	"claude-3-haiku":    {0.00025, 0.00125},
+	"claude-3-5-haiku":  {0.0008, 0.004},
+	"claude-3-sonnet":   {0.003, 0.015},
+	"claude-3-5-sonnet": {0.003, 0.015},
+	"claude-3-7-sonnet": {0.003, 0.015},
+	"claude-3-opus":     {0.015, 0.075},
+	"claude-sonnet-4":   {0.003, 0.015},
+
+	"nova-micro":    {0.000035, 0.00014},
+	"nova-lite":     {0.00006, 0.00024},
+	"nova-pro":      {0.0008, 0.0032},
+	"llama3-8b":     {0.0003, 0.0006},
+	"llama3-70b":    {0.00265, 0.0035},
+	"mistral-large": {0.004, 0.012},
+	"mixtral-8x7b":  {0.00045, 0.0007},
+}
+
+// Long-context pricing: requests whose input exceeds 200k tokens (only
+// possible under the 1M-context beta) bill input at 2x and output at 1.5x
+// the standard rate.
+const (
+	longContextThreshold     = 200000
+	longContextInMultiplier  = 2.0
+	longContextOutMultiplier = 1.5
+)
+
+// tierMultipliers approximates the service-tier premium or discount over
+// standard on-demand pricing.
+var tierMultipliers = map[string]float64{
+	"priority": 1.25,
+	"flex":     0.90,
+}
+
+// EstimateCost returns the approximate on-demand USD cost of the usage for
+// the given model, and whether pricing for the model is known.
+func EstimateCost(modelID string, u Usage) (usd float64, known bool) {
+	return EstimateCostTier(modelID, u, "")
+}
+
+// EstimateCostTier is EstimateCost adjusted for a service tier; unknown or
+// empty tiers price as standard.
+func EstimateCostTier(modelID string, u Usage, tier string) (usd float64, known bool) {
+	multiplier := 1.0
+	if m, ok := tierMultipliers[tier]; ok {
+		multiplier = m
+	}
+	for key, p := range modelPricing {
+		if strings.Contains(modelID, key) {
+			in, out := p.in, p.out
+			if u.InputTokens > longContextThreshold && IsAnthropicModel(modelID) {
+				in *= longContextInMultiplier
+				out *= longContextOutMultiplier
+			}
+			return (float64(u.InputTokens)/1000*in + float64(u.OutputTokens)/1000*out) * multiplier, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,124 @@
+package claude
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Application inference profiles carry their own ARN, tags, and metrics on
+// the Bedrock side; locally we remember name → ARN so -model can name a
+// profile instead of pasting its ARN. The mapping lives in
+// ~/.claude-bedrock-cli/profiles.json, written by the profiles command and
+// consulted transparently when a client is built.
+
+// Profile is one locally recorded application inference profile.
+type Profile struct {
+	Name    string    `json:"name"`
+	ARN     string    `json:"arn"`
+	Source  string    `json:"source"` // the model or profile the ARN was created from
+	Created time.Time `json:"created"`
+}
+
+func profilesPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".claude-bedrock-cli", "profiles.json"), nil
+}
+
+// LoadProfiles reads the locally recorded profiles; a missing file is an
+// empty list.
+func LoadProfiles() ([]Profile, error) {
+	path, err := profilesPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cfg struct {
+		Profiles []Profile `json:"profiles"`
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("corrupt profile store %s: %w", path, err)
+	}
+	return cfg.Profiles, nil
+}
+
+// SaveProfile records (or replaces) a profile by name.
+func SaveProfile(p Profile) error {
+	profiles, err := LoadProfiles()
+	if err != nil {
+		return err
+	}
+	replaced := false
+	for i, existing := range profiles {
+		if existing.Name == p.Name {
+			profiles[i] = p
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		profiles = append(profiles, p)
+	}
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+	return writeProfiles(profiles)
+}
+
+// DeleteProfile removes the named profile from the local store, reporting
+// whether it existed. The Bedrock-side profile is untouched.
+func DeleteProfile(name string) (bool, error) {
+	profiles, err := LoadProfiles()
+	if err != nil {
+		return false, err
+	}
+	for i, p := range profiles {
+		if p.Name == name {
+			return true, writeProfiles(append(profiles[:i], profiles[i+1:]...))
+		}
+	}
+	return false, nil
+}
+
+func writeProfiles(profiles []Profile) error {
+	path, err := profilesPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(struct {
+		Profiles []Profile `json:"profiles"`
+	}{profiles}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// profileARN resolves a -model value naming a recorded profile to its ARN;
+// unknown names (and load failures) leave the value alone so ordinary model
+// IDs pass through untouched.
+func profileARN(model string) string {
+	profiles, err := LoadProfiles()
+	if err != nil {
+		return model
+	}
+	for _, p := range profiles {
+		if p.Name == model {
+			return p.ARN
+		}
+	}
+	return model
+}
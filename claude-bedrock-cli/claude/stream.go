@@ -0,0 +1,238 @@
+package claude
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+)
+
+// Event is one typed streaming event from StreamEvents. The concrete types
+// are TextDelta, ReasoningDelta, ToolCall, UsageEvent, Stop, and
+// StreamError, so consumers switch over a small closed set instead of
+// re-implementing the raw types.ConverseStreamOutput switch.
+type Event interface {
+	isEvent()
+}
+
+// TextDelta is an incremental fragment of the answer text.
+type TextDelta struct {
+	Text string
+}
+
+// ReasoningDelta is an incremental fragment of extended-thinking output on
+// models that emit it.
+type ReasoningDelta struct {
+	Text string
+}
+
+// ToolCall is a complete tool invocation request; it is emitted once the
+// tool's input has finished streaming.
+type ToolCall struct {
+	ID        string
+	Name      string
+	InputJSON string
+}
+
+// UsageEvent carries the token usage from the stream's metadata.
+type UsageEvent struct {
+	Usage Usage
+}
+
+// Stop marks the end of generation with its stop reason.
+type Stop struct {
+	Reason types.StopReason
+}
+
+// StreamError reports a mid-stream failure; it is the final event when the
+// stream breaks.
+type StreamError struct {
+	Err error
+}
+
+func (TextDelta) isEvent()      {}
+func (ReasoningDelta) isEvent() {}
+func (ToolCall) isEvent()       {}
+func (UsageEvent) isEvent()     {}
+func (Stop) isEvent()           {}
+func (StreamError) isEvent()    {}
+
+// TextReader sends the request and returns a reader that yields the
+// answer's bytes as they stream, so the response plugs into anything
+// expecting an io.Reader (an HTTP response copy, an S3 upload, ...). A
+// mid-stream failure surfaces as the reader's error; reaching EOF means
+// the answer completed.
+func (c *Client) TextReader(ctx context.Context, req Request) (io.Reader, error) {
+	events, err := c.StreamEvents(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		abandoned := false
+		for ev := range events {
+			switch ev := ev.(type) {
+			case TextDelta:
+				if abandoned {
+					continue
+				}
+				if _, err := pw.Write([]byte(ev.Text)); err != nil {
+					// The reader went away; keep draining so the stream
+					// goroutine can finish instead of blocking forever.
+					abandoned = true
+				}
+			case StreamError:
+				pw.CloseWithError(ev.Err)
+				abandoned = true
+			}
+		}
+		pw.Close()
+	}()
+	return pr, nil
+}
+
+// StreamStats accounts for output that ConverseStreamTo wrote without
+// buffering it: how many bytes went out, a rolling SHA-256 of them for
+// integrity checks downstream, and the invocation's token usage.
+type StreamStats struct {
+	Bytes  int64
+	SHA256 string
+	Usage  Usage
+}
+
+// ConverseStreamTo sends the request and writes the answer's text to w as it
+// streams. Unlike ConverseStream it never assembles the full response, so a
+// 128k-token output costs a delta-sized buffer rather than megabytes of
+// builder — the mode to use when the answer is only headed for a file or
+// socket. A write failure cancels the upstream stream.
+func (c *Client) ConverseStreamTo(ctx context.Context, req Request, w io.Writer) (StreamStats, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := c.StreamEvents(ctx, req)
+	if err != nil {
+		return StreamStats{}, err
+	}
+	var stats StreamStats
+	hash := sha256.New()
+	var writeErr error
+	for ev := range events {
+		switch ev := ev.(type) {
+		case TextDelta:
+			if writeErr != nil {
+				continue
+			}
+			n, err := io.WriteString(w, ev.Text)
+			stats.Bytes += int64(n)
+			hash.Write([]byte(ev.Text[:n]))
+			if err != nil {
+				// Stop the upstream stream but keep draining the channel so
+				// the producer goroutine can finish.
+				writeErr = fmt.Errorf("stream to: %w", err)
+				cancel()
+			}
+		case UsageEvent:
+			stats.Usage = ev.Usage
+		case StreamError:
+			if writeErr == nil {
+				writeErr = ev.Err
+			}
+		}
+	}
+	stats.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	return stats, writeErr
+}
+
+// StreamEvents sends the request and returns a channel of typed events.
+// The channel closes when the stream ends; a mid-stream failure arrives as
+// a final StreamError. Cancelling ctx abandons the stream.
+func (c *Client) StreamEvents(ctx context.Context, req Request) (<-chan Event, error) {
+	model := c.modelFor(req)
+	if err := ValidateRequest(model, req); err != nil {
+		return nil, fmt.Errorf("stream events: %w", err)
+	}
+	c.last.setStopReason("")
+	out, err := c.rt.ConverseStream(ctx, &bedrockruntime.ConverseStreamInput{
+		ModelId:                      aws.String(model),
+		Messages:                     req.Messages,
+		System:                       c.systemBlocks(req),
+		InferenceConfig:              c.inferenceConfig(req),
+		ToolConfig:                   c.toolConfig(req),
+		GuardrailConfig:              c.guardrailStreamConfig(req),
+		PerformanceConfig:            c.performanceConfig(req),
+		AdditionalModelRequestFields: req.additionalFieldsFor(model),
+		RequestMetadata:              req.Metadata,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("stream events: %w", err)
+	}
+
+	ch := make(chan Event)
+	go func() {
+		stream := out.GetStream()
+		defer close(ch)
+		defer stream.Close()
+		send := func(ev Event) bool {
+			select {
+			case ch <- ev:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		var toolID, toolName string
+		var toolInput strings.Builder
+		for event := range stream.Events() {
+			switch ev := event.(type) {
+			case *types.ConverseStreamOutputMemberContentBlockStart:
+				if start, ok := ev.Value.Start.(*types.ContentBlockStartMemberToolUse); ok {
+					toolID = aws.ToString(start.Value.ToolUseId)
+					toolName = aws.ToString(start.Value.Name)
+					toolInput.Reset()
+				}
+			case *types.ConverseStreamOutputMemberContentBlockDelta:
+				switch delta := ev.Value.Delta.(type) {
+				case *types.ContentBlockDeltaMemberText:
+					if !send(TextDelta{Text: delta.Value}) {
+						return
+					}
+				case *types.ContentBlockDeltaMemberReasoningContent:
+					if text, ok := delta.Value.(*types.ReasoningContentBlockDeltaMemberText); ok {
+						if !send(ReasoningDelta{Text: text.Value}) {
+							return
+						}
+					}
+				case *types.ContentBlockDeltaMemberToolUse:
+					toolInput.WriteString(aws.ToString(delta.Value.Input))
+				}
+			case *types.ConverseStreamOutputMemberContentBlockStop:
+				if toolName != "" {
+					call := ToolCall{ID: toolID, Name: toolName, InputJSON: toolInput.String()}
+					toolID, toolName = "", ""
+					if !send(call) {
+						return
+					}
+				}
+			case *types.ConverseStreamOutputMemberMessageStop:
+				c.last.setStopReason(ev.Value.StopReason)
+				if !send(Stop{Reason: ev.Value.StopReason}) {
+					return
+				}
+			case *types.ConverseStreamOutputMemberMetadata:
+				if !send(UsageEvent{Usage: tokenUsage(ev.Value.Usage)}) {
+					return
+				}
+			}
+		}
+		if err := stream.Err(); err != nil {
+			send(StreamError{Err: fmt.Errorf("stream events: %w", err)})
+		}
+	}()
+	return ch, nil
+}
@@ -0,0 +1,121 @@
+package claude
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"unicode"
+)
+
+// EstimateTextTokens estimates how many input tokens text costs under
+// modelID, without calling the API. Claude tokenizers average a little
+// under four characters per token for Latin-script text, while CJK
+// scripts run close to one token per character; the estimate counts the
+// two populations separately. Apply a Calibration factor for numbers
+// that track an account's real traffic.
+func EstimateTextTokens(modelID, text string) int {
+	cjk := 0
+	other := 0
+	for _, r := range text {
+		if unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) ||
+			unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r) {
+			cjk++
+		} else {
+			other++
+		}
+	}
+	estimate := cjk + (other*10+19)/38 // other/3.8, rounded
+	if estimate == 0 && len(text) > 0 {
+		estimate = 1
+	}
+	return estimate
+}
+
+// EstimateImageTokens estimates the token cost of an image from its pixel
+// dimensions: roughly (width x height)/750, capped where the API
+// downscales oversized images.
+func EstimateImageTokens(width, height int) int {
+	const maxImageTokens = 1600
+	tokens := width * height / 750
+	if tokens > maxImageTokens {
+		return maxImageTokens
+	}
+	if tokens < 1 {
+		return 1
+	}
+	return tokens
+}
+
+// Calibration tracks, per model, how estimates compare with the token
+// counts Bedrock actually reports, as an exponentially weighted
+// actual/estimated ratio persisted across runs.
+type Calibration struct {
+	Factors map[string]float64 `json:"factors"`
+
+	path string
+}
+
+// calibrationWeight is how much one new observation moves the factor.
+const calibrationWeight = 0.2
+
+// LoadCalibration reads the calibration file from the user cache
+// directory, returning an empty calibration when none exists yet.
+func LoadCalibration() (*Calibration, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	c := &Calibration{
+		Factors: map[string]float64{},
+		path:    filepath.Join(base, "claude-bedrock-cli", "token-calibration.json"),
+	}
+	data, err := os.ReadFile(c.path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	if c.Factors == nil {
+		c.Factors = map[string]float64{}
+	}
+	return c, nil
+}
+
+// Factor returns the correction multiplier for a model; 1 when nothing
+// has been observed yet.
+func (c *Calibration) Factor(modelID string) float64 {
+	if f, ok := c.Factors[modelID]; ok && f > 0 {
+		return f
+	}
+	return 1
+}
+
+// Observe folds one (estimated, actual) pair into the model's factor.
+func (c *Calibration) Observe(modelID string, estimated, actual int) {
+	if estimated <= 0 || actual <= 0 {
+		return
+	}
+	ratio := float64(actual) / float64(estimated)
+	old, ok := c.Factors[modelID]
+	if !ok {
+		c.Factors[modelID] = ratio
+		return
+	}
+	c.Factors[modelID] = old*(1-calibrationWeight) + ratio*calibrationWeight
+}
+
+// Save persists the calibration for future runs.
+func (c *Calibration) Save() error {
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, data, 0o644)
+}
@@ -0,0 +1,104 @@
+package claude
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Validator checks an assembled response. A non-nil error fails validation;
+// its message is what the model sees when the request is retried, so make it
+// actionable ("response must contain a JSON array", not "bad output").
+type Validator func(text string) error
+
+// HasJSONArray validates that the response contains a parseable JSON array.
+func HasJSONArray() Validator {
+	return func(text string) error {
+		start := strings.Index(text, "[")
+		end := strings.LastIndex(text, "]")
+		if start >= 0 && end > start {
+			var arr []any
+			if json.Unmarshal([]byte(text[start:end+1]), &arr) == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("response must contain a valid JSON array")
+	}
+}
+
+// HasJSONObject validates that the response contains a parseable JSON
+// object.
+func HasJSONObject() Validator {
+	return func(text string) error {
+		start := strings.Index(text, "{")
+		end := strings.LastIndex(text, "}")
+		if start >= 0 && end > start {
+			var obj map[string]any
+			if json.Unmarshal([]byte(text[start:end+1]), &obj) == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("response must contain a valid JSON object")
+	}
+}
+
+// UnderWords validates that the response stays under n words.
+func UnderWords(n int) Validator {
+	return func(text string) error {
+		if words := len(strings.Fields(text)); words > n {
+			return fmt.Errorf("response must be under %d words; it has %d", n, words)
+		}
+		return nil
+	}
+}
+
+// Matches validates the response against a regular expression.
+func Matches(re *regexp.Regexp) Validator {
+	return func(text string) error {
+		if !re.MatchString(text) {
+			return fmt.Errorf("response must match %s", re)
+		}
+		return nil
+	}
+}
+
+// ConverseValidated is ConverseUsage with post-validation: the response is
+// run through each validator, and on failure the failing answer and the
+// validation error are appended to the conversation and the request
+// reissued, up to maxAttempts attempts total (<= 0 applies a default of 3).
+// The returned usage sums all attempts. When every attempt fails, the last
+// response is returned alongside the last validation error, so callers can
+// still salvage it.
+func (c *Client) ConverseValidated(ctx context.Context, req Request, maxAttempts int, validators ...Validator) (string, Usage, error) {
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+	base := req.Messages
+	var total Usage
+	var text string
+	var vErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		var usage Usage
+		var err error
+		text, usage, err = c.ConverseUsage(ctx, req)
+		total.Add(usage)
+		if err != nil {
+			return text, total, err
+		}
+		vErr = nil
+		for _, validate := range validators {
+			if vErr = validate(text); vErr != nil {
+				break
+			}
+		}
+		if vErr == nil {
+			return text, total, nil
+		}
+		req.Messages = append(append([]Message(nil), base...),
+			AssistantMessage(text),
+			UserMessage(fmt.Sprintf("That response failed validation: %s. Respond again, correcting this.", vErr)))
+	}
+	return text, total, fmt.Errorf("validate: still failing after %d attempts: %w", maxAttempts, vErr)
+}
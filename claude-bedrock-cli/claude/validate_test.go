@@ -0,0 +1,55 @@
+package claude
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestHasJSONArray(t *testing.T) {
+	v := HasJSONArray()
+	if err := v(`Here you go: ["a", "b"] — done.`); err != nil {
+		t.Errorf("array inside prose rejected: %v", err)
+	}
+	if err := v(`[1, 2, 3]`); err != nil {
+		t.Errorf("bare array rejected: %v", err)
+	}
+	if v(`no array here`) == nil {
+		t.Error("accepted text with no array")
+	}
+	if v(`[broken`) == nil {
+		t.Error("accepted an unterminated array")
+	}
+}
+
+func TestHasJSONObject(t *testing.T) {
+	v := HasJSONObject()
+	if err := v(`The result is {"ok": true}.`); err != nil {
+		t.Errorf("object inside prose rejected: %v", err)
+	}
+	if v(`nothing structured`) == nil {
+		t.Error("accepted text with no object")
+	}
+	if v(`{"key": }`) == nil {
+		t.Error("accepted malformed JSON")
+	}
+}
+
+func TestUnderWords(t *testing.T) {
+	v := UnderWords(3)
+	if err := v("one two three"); err != nil {
+		t.Errorf("at-limit text rejected: %v", err)
+	}
+	if v("one two three four") == nil {
+		t.Error("accepted text over the word limit")
+	}
+}
+
+func TestMatches(t *testing.T) {
+	v := Matches(regexp.MustCompile(`(?m)^SCORE: \d+$`))
+	if err := v("analysis...\nSCORE: 8"); err != nil {
+		t.Errorf("matching text rejected: %v", err)
+	}
+	if v("no score line") == nil {
+		t.Error("accepted text missing the pattern")
+	}
+}
@@ -0,0 +1,85 @@
+package claude
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ClaimCheck is the verdict on one factual claim extracted from an answer.
+type ClaimCheck struct {
+	Claim     string `json:"claim"`
+	Supported bool   `json:"supported"`
+	// Note is the verifier's brief reasoning, recorded for unsupported
+	// claims so a reviewer can see why the claim was flagged.
+	Note string `json:"note,omitempty"`
+}
+
+// VerifyClaims runs a verification second-pass over an answer: it extracts
+// the answer's distinct factual claims, then checks each against the source
+// text with a targeted follow-up prompt. At most maxClaims are checked
+// (<= 0 applies a default of 8). The returned usage sums every verification
+// call — roughly one cheap invocation per claim, so consider running it on
+// a Haiku-class client.
+func (c *Client) VerifyClaims(ctx context.Context, source, answer string, maxClaims int) ([]ClaimCheck, Usage, error) {
+	if maxClaims <= 0 {
+		maxClaims = 8
+	}
+	var total Usage
+
+	extract := fmt.Sprintf("List the distinct factual claims made in the following answer as a JSON array of strings, at most %d, most load-bearing first. Claims only — no opinions or hedges.\n\n<answer>\n%s\n</answer>", maxClaims, answer)
+	text, usage, err := c.ConverseValidated(ctx, Request{
+		Messages:  []Message{UserMessage(extract)},
+		MaxTokens: 1024,
+	}, 2, HasJSONArray())
+	total.Add(usage)
+	if err != nil {
+		return nil, total, fmt.Errorf("verify: extracting claims: %w", err)
+	}
+	claims, err := parseClaimArray(text)
+	if err != nil {
+		return nil, total, fmt.Errorf("verify: %w", err)
+	}
+	if len(claims) > maxClaims {
+		claims = claims[:maxClaims]
+	}
+
+	checks := make([]ClaimCheck, 0, len(claims))
+	for _, claim := range claims {
+		verdict, usage, err := c.ConverseUsage(ctx, Request{
+			System:    "You verify claims strictly against a source text. Answer SUPPORTED only when the source states or directly entails the claim.",
+			Messages:  []Message{UserMessage(fmt.Sprintf("<source>\n%s\n</source>\n\nClaim: %q\n\nIs this claim supported by the source? Reply with SUPPORTED or UNSUPPORTED on the first line, then one sentence of reasoning.", source, claim))},
+			MaxTokens: 256,
+		})
+		total.Add(usage)
+		if err != nil {
+			return checks, total, fmt.Errorf("verify: checking claim %q: %w", claim, err)
+		}
+		check := ClaimCheck{Claim: claim}
+		line, rest, _ := strings.Cut(strings.TrimSpace(verdict), "\n")
+		check.Supported = strings.HasPrefix(strings.ToUpper(strings.TrimSpace(line)), "SUPPORTED")
+		if !check.Supported {
+			check.Note = strings.TrimSpace(rest)
+			if check.Note == "" {
+				check.Note = strings.TrimSpace(line)
+			}
+		}
+		checks = append(checks, check)
+	}
+	return checks, total, nil
+}
+
+// parseClaimArray pulls the JSON array of claim strings out of a response.
+func parseClaimArray(text string) ([]string, error) {
+	start := strings.Index(text, "[")
+	end := strings.LastIndex(text, "]")
+	if start < 0 || end <= start {
+		return nil, fmt.Errorf("no claim array in %q", text)
+	}
+	var claims []string
+	if err := json.Unmarshal([]byte(text[start:end+1]), &claims); err != nil {
+		return nil, fmt.Errorf("claim array: %w", err)
+	}
+	return claims, nil
+}
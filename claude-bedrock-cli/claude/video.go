@@ -0,0 +1,80 @@
+package claude
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+)
+
+// Video input rides on the Converse video content block, supported by Nova
+// and other video-capable models on Bedrock. Small files travel inline as
+// bytes; anything larger must be referenced by S3 URI, which Bedrock reads
+// directly.
+
+// MaxInlineVideoBytes is the largest video that fits inline in a Converse
+// request payload; bigger files have to come from S3.
+const MaxInlineVideoBytes = 25 << 20
+
+// MaxVideoBytes is the service ceiling for an S3-referenced video.
+const MaxVideoBytes = 1 << 30
+
+// videoFormats maps file extensions to Converse video formats.
+var videoFormats = map[string]types.VideoFormat{
+	".mp4":  types.VideoFormatMp4,
+	".mov":  types.VideoFormatMov,
+	".mkv":  types.VideoFormatMkv,
+	".webm": types.VideoFormatWebm,
+	".flv":  types.VideoFormatFlv,
+	".mpeg": types.VideoFormatMpeg,
+	".mpg":  types.VideoFormatMpg,
+	".wmv":  types.VideoFormatWmv,
+	".3gp":  types.VideoFormatThreeGp,
+}
+
+// VideoFormatForPath derives the Converse video format from a path or S3
+// URI extension, with an error naming the supported formats otherwise.
+func VideoFormatForPath(path string) (types.VideoFormat, error) {
+	if format, ok := videoFormats[strings.ToLower(filepath.Ext(path))]; ok {
+		return format, nil
+	}
+	exts := make([]string, 0, len(videoFormats))
+	for ext := range videoFormats {
+		exts = append(exts, ext)
+	}
+	sort.Strings(exts)
+	return "", fmt.Errorf("unsupported video format %q (supported: %s)", filepath.Ext(path), strings.Join(exts, ", "))
+}
+
+// Video is a video attachment for a multimodal message: either inline Data
+// or an S3URI the service reads directly, never both.
+type Video struct {
+	Format types.VideoFormat
+	Data   []byte
+	S3URI  string
+}
+
+// UserMessageWithVideo builds a user message carrying a video block followed
+// by a text block.
+func UserMessageWithVideo(text string, video Video) types.Message {
+	var source types.VideoSource
+	if video.S3URI != "" {
+		source = &types.VideoSourceMemberS3Location{
+			Value: types.S3Location{Uri: aws.String(video.S3URI)},
+		}
+	} else {
+		source = &types.VideoSourceMemberBytes{Value: video.Data}
+	}
+	return types.Message{
+		Role: types.ConversationRoleUser,
+		Content: []types.ContentBlock{
+			&types.ContentBlockMemberVideo{
+				Value: types.VideoBlock{Format: video.Format, Source: source},
+			},
+			&types.ContentBlockMemberText{Value: text},
+		},
+	}
+}
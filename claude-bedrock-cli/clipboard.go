@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// clipboardImageCommands are tried in order until one succeeds; each must
+// print PNG bytes from the system clipboard to stdout.
+var clipboardImageCommands = [][]string{
+	{"wl-paste", "-t", "image/png"},                               // Wayland
+	{"xclip", "-selection", "clipboard", "-t", "image/png", "-o"}, // X11
+	{"pngpaste", "-"}, // macOS (brew install pngpaste)
+}
+
+// readClipboardImage returns the clipboard contents as PNG bytes, using
+// whichever platform clipboard tool is available.
+func readClipboardImage() ([]byte, error) {
+	var lastErr error
+	for _, argv := range clipboardImageCommands {
+		if _, err := exec.LookPath(argv[0]); err != nil {
+			continue
+		}
+		out, err := exec.Command(argv[0], argv[1:]...).Output()
+		if err != nil {
+			lastErr = fmt.Errorf("%s: %w", argv[0], err)
+			continue
+		}
+		if len(out) == 0 {
+			lastErr = fmt.Errorf("%s: clipboard contains no image", argv[0])
+			continue
+		}
+		return out, nil
+	}
+	if lastErr != nil {
+		return nil, lastErr
+	}
+	return nil, fmt.Errorf("no clipboard tool found (install wl-clipboard, xclip, or pngpaste)")
+}
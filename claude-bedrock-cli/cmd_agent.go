@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/agent"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+)
+
+// runAgent answers a prompt with tool use: the model may call the registered
+// tools, gated by a per-tool permission policy.
+func runAgent(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("agent", flag.ExitOnError)
+	model := fs.String("model", "", "Bedrock model ID")
+	region := fs.String("region", "", "AWS region")
+	system := fs.String("system", "", "system prompt")
+	maxTokens := fs.Int("max-tokens", 4096, "max output tokens per model turn")
+	maxSteps := fs.Int("max-steps", agent.DefaultMaxSteps, "abort after this many model turns")
+	permissions := fs.String("permissions", "", "JSON policy file mapping tools to allow/approve/deny")
+	gateWrites := fs.Bool("gate-writes", false, "always pause for approval before tools that change state")
+	yes := fs.Bool("yes", false, "auto-approve tool calls that would ask for confirmation")
+	verbose := fs.Bool("verbose", false, "log tool calls to stderr")
+	computer := fs.String("computer", "", "enable the computer-use beta against a headless browser opened at this URL")
+	browser := fs.Bool("browser", false, "enable the browser tool (navigate, extract, click, screenshot)")
+	editorRoot := fs.String("editor-root", "", "enable the text-editor tool sandboxed to this directory")
+	dryRun := fs.Bool("dry-run", false, "editor tool previews edits as diffs instead of writing")
+	tokenEfficient := fs.Bool("token-efficient", false, "enable the token-efficient-tools beta")
+	pluginsDir := fs.String("plugins", "", "directory of plugin tool executables and .wasm modules (default ~/.claude-bedrock-cli/plugins)")
+	wasmFS := fs.String("wasm-fs", "", "directory WASM plugin tools may read (default: no filesystem access)")
+	traceFile := fs.String("trace", "", "write an execution trace of the run to this file")
+	traceFormat := fs.String("trace-format", "json", "trace format: json, mermaid, or dot")
+	fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		return fmt.Errorf("agent: usage: agent [options] <prompt>")
+	}
+	prompt := strings.Join(fs.Args(), " ")
+
+	client, err := claude.NewClient(ctx, *region, *model)
+	if err != nil {
+		return err
+	}
+	policy := agent.DefaultPolicy()
+	if *permissions != "" {
+		if policy, err = agent.LoadPolicy(*permissions); err != nil {
+			return err
+		}
+	}
+	if *gateWrites {
+		policy.GateWrites = true
+	}
+
+	tools := []agent.Tool{agent.Calculator{}, agent.Shell{}}
+	pluginTools, err := agent.DiscoverPlugins(ctx, *pluginsDir, *wasmFS)
+	if err != nil {
+		return err
+	}
+	tools = append(tools, pluginTools...)
+	if *browser {
+		b := agent.NewBrowser(ctx)
+		defer b.Close()
+		tools = append(tools, b)
+	}
+	if *editorRoot != "" {
+		root, err := filepath.Abs(*editorRoot)
+		if err != nil {
+			return err
+		}
+		tools = append(tools, &agent.Editor{Root: root, DryRun: *dryRun})
+	}
+	if *computer != "" {
+		const width, height = 1280, 800
+		exec, err := agent.NewBrowserExecutor(ctx, *computer, width, height)
+		if err != nil {
+			return err
+		}
+		defer exec.Close()
+		tools = append(tools, agent.ComputerTool{Exec: exec, Width: width, Height: height})
+	}
+
+	a := &agent.Agent{
+		Client:         client,
+		Tools:          tools,
+		Policy:         policy,
+		MaxSteps:       *maxSteps,
+		Confirm:        confirmOnTerminal,
+		Verbose:        *verbose,
+		TokenEfficient: *tokenEfficient,
+		OnEvent:        renderToolEvent,
+	}
+	if *yes {
+		a.Confirm = func(string, string) bool { return true }
+	}
+	if *traceFile != "" {
+		switch *traceFormat {
+		case "json", "mermaid", "dot":
+		default:
+			return fmt.Errorf("agent: unknown trace format %q (have json, mermaid, dot)", *traceFormat)
+		}
+		a.Trace = &agent.Trace{}
+		// Write the trace even when the run errors out; a partial trace is
+		// exactly what's needed to debug the failure.
+		defer func() {
+			if err := writeTrace(*traceFile, *traceFormat, a.Trace); err != nil {
+				fmt.Fprintf(os.Stderr, "warning: writing trace: %v\n", err)
+			}
+		}()
+	}
+
+	text, usage, err := a.Run(ctx, *system, prompt, int32(*maxTokens))
+	if err != nil {
+		return err
+	}
+	fmt.Println(text)
+	if *verbose {
+		fmt.Fprintf(os.Stderr, "tokens: %d in, %d out\n", usage.InputTokens, usage.OutputTokens)
+	}
+	return nil
+}
+
+// writeTrace exports the recorded spans in the requested format.
+func writeTrace(path, format string, trace *agent.Trace) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	switch format {
+	case "mermaid":
+		_, err = f.WriteString(trace.Mermaid())
+	case "dot":
+		_, err = f.WriteString(trace.DOT())
+	default:
+		err = trace.WriteJSON(f)
+	}
+	return err
+}
+
+// renderToolEvent shows live tool activity on stderr, so the user sees what
+// the agent is doing during otherwise silent model turns.
+func renderToolEvent(ev agent.ToolEvent) {
+	switch ev.Kind {
+	case agent.ToolExecuting:
+		fmt.Fprintf(os.Stderr, "[%s] running %s\n", ev.Tool, ev.Input)
+	case agent.ToolResultReady:
+		if ev.Err != nil {
+			fmt.Fprintf(os.Stderr, "[%s] failed: %v\n", ev.Tool, ev.Err)
+		} else {
+			fmt.Fprintf(os.Stderr, "[%s] done\n", ev.Tool)
+		}
+	}
+}
+
+// confirmOnTerminal asks the user on stderr/stdin whether a tool call may
+// run.
+func confirmOnTerminal(tool, input string) bool {
+	fmt.Fprintf(os.Stderr, "allow %s(%s)? [y/N] ", tool, input)
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes"
+}
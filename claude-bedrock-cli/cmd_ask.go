@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/chunk"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/embed"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/pack"
+)
+
+const askChunkChars = 4000
+
+func runAsk(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("ask", flag.ExitOnError)
+	file := fs.String("file", "-", "document to answer questions about (- for stdin)")
+	topK := fs.Int("top", 6, "number of most relevant chunks to include in the prompt")
+	embedModel := fs.String("embed-model", "", "Bedrock embeddings model ID")
+	model := fs.String("model", "", "Bedrock model ID")
+	region := fs.String("region", "", "AWS region")
+	maxTokens := fs.Int("max-tokens", 2048, "max output tokens")
+	budget := fs.Int("budget", 0, "pack chunks into this character budget by relevance instead of taking -top")
+	compress := fs.Bool("compress", false, "with -budget, summarize low-relevance chunks that would not fit whole")
+	fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		return fmt.Errorf("ask: usage: ask [options] \"question\"")
+	}
+	question := strings.Join(fs.Args(), " ")
+
+	text, err := readInput(*file)
+	if err != nil {
+		return err
+	}
+
+	embedder, err := embed.NewClient(ctx, *region, *embedModel)
+	if err != nil {
+		return err
+	}
+	client, err := claude.NewClient(ctx, *region, *model)
+	if err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	if *budget > 0 {
+		if err := packChunks(ctx, &sb, embedder, client, text, question, *budget, *compress); err != nil {
+			return err
+		}
+	} else {
+		relevant, err := selectRelevantChunks(ctx, embedder, text, question, *topK)
+		if err != nil {
+			return err
+		}
+		for _, p := range relevant {
+			fmt.Fprintf(&sb, "<chunk offset=\"%d\">\n%s\n</chunk>\n", p.Start, strings.TrimSpace(p.Text))
+		}
+	}
+	sb.WriteString("\nQuestion: " + question)
+	_, err = client.ConverseStream(ctx, claude.Request{
+		System: "Answer the question using only the provided document chunks. " +
+			"Each chunk is tagged with its byte offset in the original document; " +
+			"cite the chunks you relied on as [offset N]. If the chunks do not " +
+			"contain the answer, say so.",
+		Messages:  []claude.Message{claude.UserMessage(sb.String())},
+		MaxTokens: int32(*maxTokens),
+	}, func(delta string) { fmt.Print(delta) })
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	return nil
+}
+
+// packChunks scores every chunk against the question and packs as many as
+// fit the character budget, optionally compressing below-average chunks
+// instead of dropping them. The packed chunks are rendered into sb.
+func packChunks(ctx context.Context, sb *strings.Builder, embedder *embed.Client, client *claude.Client, text, question string, budget int, compress bool) error {
+	pieces := chunk.SplitWithOffsets(text, askChunkChars)
+	qvec, err := embedder.Embed(ctx, question)
+	if err != nil {
+		return fmt.Errorf("embedding question: %w", err)
+	}
+	candidates := make([]pack.Piece, len(pieces))
+	mean := 0.0
+	for i, p := range pieces {
+		vec, err := embedder.Embed(ctx, p.Text)
+		if err != nil {
+			return fmt.Errorf("embedding chunk %d/%d: %w", i+1, len(pieces), err)
+		}
+		score := embed.Cosine(qvec, vec)
+		candidates[i] = pack.Piece{
+			ID:       strconv.Itoa(p.Start),
+			Text:     strings.TrimSpace(p.Text),
+			Priority: score,
+		}
+		mean += score
+	}
+	mean /= float64(len(candidates))
+
+	packer := &pack.Packer{Budget: budget}
+	if compress {
+		packer.Compress = pack.Summarizer(client, askChunkChars/4)
+		packer.CompressBelow = mean
+	}
+	result, err := packer.Pack(ctx, candidates)
+	if err != nil {
+		return err
+	}
+	for _, p := range result.Included {
+		fmt.Fprintf(sb, "<chunk offset=%q>\n%s\n</chunk>\n", p.ID, p.Text)
+	}
+	note("packed %d/%d chunks into %d chars (%d compressed, %d dropped)",
+		len(result.Included), len(candidates), result.Chars, len(result.Compressed), len(result.Dropped))
+	return nil
+}
+
+// selectRelevantChunks embeds the question and every document chunk, ranks
+// chunks by cosine similarity, and returns the top K in document order.
+func selectRelevantChunks(ctx context.Context, embedder *embed.Client, text, question string, topK int) ([]chunk.Piece, error) {
+	pieces := chunk.SplitWithOffsets(text, askChunkChars)
+	if len(pieces) <= topK {
+		return pieces, nil
+	}
+
+	qvec, err := embedder.Embed(ctx, question)
+	if err != nil {
+		return nil, fmt.Errorf("embedding question: %w", err)
+	}
+	type scored struct {
+		piece chunk.Piece
+		score float64
+	}
+	ranked := make([]scored, 0, len(pieces))
+	for i, p := range pieces {
+		vec, err := embedder.Embed(ctx, p.Text)
+		if err != nil {
+			return nil, fmt.Errorf("embedding chunk %d/%d: %w", i+1, len(pieces), err)
+		}
+		ranked = append(ranked, scored{piece: p, score: embed.Cosine(qvec, vec)})
+	}
+	sort.Slice(ranked, func(i, j int) bool { return ranked[i].score > ranked[j].score })
+	ranked = ranked[:topK]
+	sort.Slice(ranked, func(i, j int) bool { return ranked[i].piece.Start < ranked[j].piece.Start })
+
+	selected := make([]chunk.Piece, len(ranked))
+	for i, s := range ranked {
+		selected[i] = s.piece
+	}
+	return selected, nil
+}
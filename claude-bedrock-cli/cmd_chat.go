@@ -0,0 +1,445 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chzyer/readline"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/kmscrypt"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/secrets"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/session"
+)
+
+// chatState carries everything the interactive loop and its slash commands
+// operate on.
+type chatState struct {
+	client *claude.Client
+	store  *session.Store
+	sess   *session.Session
+	system string
+	maxTok int32
+	rl     *readline.Instance
+	usage  claude.Usage   // cumulative for this process
+	draft  string         // last /edit buffer, preloaded on the next /edit
+	images []claude.Image // pasted images attached to the next turn
+}
+
+// chatCompleter tab-completes slash commands, and file paths for the
+// commands that take one.
+var chatCompleter = readline.NewPrefixCompleter(
+	readline.PcItem("/fork"),
+	readline.PcItem("/branch", readline.PcItem("list")),
+	readline.PcItem("/switch"),
+	readline.PcItem("/reset"),
+	readline.PcItem("/model"),
+	readline.PcItem("/system"),
+	readline.PcItem("/save"),
+	readline.PcItem("/tokens"),
+	readline.PcItem("/paste-image"),
+	readline.PcItem("/prompt"),
+	readline.PcItem("/edit"),
+	readline.PcItem("/export", readline.PcItemDynamic(completeFilePaths)),
+	readline.PcItem("/help"),
+	readline.PcItem("/quit"),
+)
+
+// completeFilePaths lists directory entries matching the partial path at the
+// end of the line.
+func completeFilePaths(line string) []string {
+	fields := strings.Fields(line)
+	partial := ""
+	if len(fields) > 1 && !strings.HasSuffix(line, " ") {
+		partial = fields[len(fields)-1]
+	}
+	dir, prefix := filepath.Split(partial)
+	searchDir := dir
+	if searchDir == "" {
+		searchDir = "."
+	}
+	entries, err := os.ReadDir(searchDir)
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), prefix) {
+			p := dir + e.Name()
+			if e.IsDir() {
+				p += string(filepath.Separator)
+			}
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// editDraft opens $EDITOR (vi when unset) on a temp file preloaded with the
+// previous draft and returns the edited content.
+func (st *chatState) editDraft() (string, error) {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+	tmp, err := os.CreateTemp("", "claude-bedrock-cli-*.md")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.WriteString(st.draft); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	tmp.Close()
+
+	cmd := exec.Command(editor, tmp.Name())
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s: %w", editor, err)
+	}
+	data, err := os.ReadFile(tmp.Name())
+	if err != nil {
+		return "", err
+	}
+	st.draft = string(data)
+	return strings.TrimSpace(string(data)), nil
+}
+
+func runChat(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("chat", flag.ExitOnError)
+	model := fs.String("model", "", "Bedrock model ID")
+	region := fs.String("region", "", "AWS region")
+	system := fs.String("system", "", "system prompt")
+	maxTokens := fs.Int("max-tokens", 4096, "max output tokens per turn")
+	resume := fs.String("resume", "", "session ID to resume")
+	transcript := fs.String("transcript", "", "write the transcript to this .md or .html file on exit")
+	sessionDir := fs.String("session-dir", "", "session store directory (default: ~/.claude-bedrock-cli/sessions)")
+	kmsKey := fs.String("kms-key", "", "KMS key ID/ARN/alias for encrypting saved sessions at rest")
+	var tags tagFlags
+	fs.Var(&tags, "tag", "cost-allocation key=value pair, repeatable; also read from CLAUDE_CLI_TAGS")
+	fs.Parse(args)
+	applyTags(tags)
+
+	client, err := claude.NewClient(ctx, *region, *model)
+	if err != nil {
+		return err
+	}
+	if secrets.IsRef(*system) {
+		resolver, err := secrets.NewResolver(ctx, *region)
+		if err != nil {
+			return err
+		}
+		if *system, err = resolver.Resolve(ctx, *system); err != nil {
+			return err
+		}
+	}
+	store, err := session.OpenStore(*sessionDir)
+	if err != nil {
+		return err
+	}
+	applyRetentionEnv(store)
+	if redactEnabled() {
+		store.Redact = func(ctx context.Context, text string) (string, error) {
+			return redactForStorage(ctx, text), nil
+		}
+	}
+	if *kmsKey != "" {
+		cipher, err := kmscrypt.New(ctx, *region, *kmsKey)
+		if err != nil {
+			return err
+		}
+		store.Cipher = cipher
+	}
+	var sess *session.Session
+	if *resume != "" {
+		if sess, err = store.Load(ctx, *resume); err != nil {
+			return err
+		}
+	} else {
+		sess = session.New()
+	}
+
+	home, _ := os.UserHomeDir()
+	rl, err := readline.NewEx(&readline.Config{
+		Prompt:          "> ",
+		HistoryFile:     filepath.Join(home, ".claude-bedrock-cli", "history"),
+		AutoComplete:    chatCompleter,
+		InterruptPrompt: "^C",
+	})
+	if err != nil {
+		return err
+	}
+	defer rl.Close()
+
+	st := &chatState{
+		client: client,
+		store:  store,
+		sess:   sess,
+		system: *system,
+		maxTok: int32(*maxTokens),
+		rl:     rl,
+	}
+	fmt.Printf("session %s (branch %s, %d turns) — /help for commands, /quit to exit\n",
+		sess.ID, sess.Branch, len(sess.Turns()))
+
+	for {
+		st.rl.SetPrompt(fmt.Sprintf("[%s]> ", st.sess.Branch))
+		raw, err := st.rl.Readline()
+		if errors.Is(err, readline.ErrInterrupt) {
+			continue
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "/") {
+			quit, err := st.slashCommand(ctx, line)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+			if quit {
+				break
+			}
+			continue
+		}
+		if err := st.sendTurn(ctx, line); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}
+	autoTitleSession(ctx, st.client, st.sess)
+	if err := st.store.Save(ctx, st.sess); err != nil {
+		return err
+	}
+	if *transcript != "" {
+		return exportTranscript(ctx, st.sess, *transcript)
+	}
+	return nil
+}
+
+// sendTurn appends the user message to the active branch, streams the
+// assistant reply, records it, and persists the session.
+func (st *chatState) sendTurn(ctx context.Context, text string) error {
+	st.sess.Append("user", text)
+	turns := st.sess.Turns()
+	messages := make([]claude.Message, 0, len(turns))
+	for i, turn := range turns {
+		switch {
+		case turn.Role == "assistant":
+			messages = append(messages, claude.AssistantMessage(turn.Text))
+		case i == len(turns)-1 && len(st.images) > 0:
+			// Pasted images ride on the current turn only; the persisted
+			// transcript keeps just the text.
+			messages = append(messages, claude.UserMessageWithImages(turn.Text, st.images))
+		default:
+			messages = append(messages, claude.UserMessage(turn.Text))
+		}
+	}
+	st.images = nil
+	start := time.Now()
+	reply, usage, err := st.client.ConverseStreamUsage(ctx, claude.Request{
+		System:    st.system,
+		Messages:  messages,
+		MaxTokens: st.maxTok,
+	}, func(delta string) { fmt.Print(delta) })
+	fmt.Println()
+	st.usage.Add(usage)
+	if err == nil {
+		recordInvocation(ctx, "chat", st.client.ModelID, text, reply, st.client.LastRequestID(), usage, time.Since(start))
+	}
+	if err != nil {
+		// Drop the unanswered user turn so the branch stays consistent.
+		branch := st.sess.Branches[st.sess.Branch]
+		st.sess.Branches[st.sess.Branch] = branch[:len(branch)-1]
+		return err
+	}
+	st.sess.Append("assistant", reply)
+	return st.store.Save(ctx, st.sess)
+}
+
+// exportTranscript writes the session transcript to path; the extension
+// selects Markdown (.md) or standalone HTML (.html). The rendered document
+// passes through the storage redaction policy like any other persisted copy.
+func exportTranscript(ctx context.Context, sess *session.Session, path string) error {
+	var data []byte
+	switch {
+	case strings.HasSuffix(path, ".html"), strings.HasSuffix(path, ".htm"):
+		data = session.ExportHTML(sess)
+	case strings.HasSuffix(path, ".md"), strings.HasSuffix(path, ".markdown"):
+		data = session.ExportMarkdown(sess)
+	default:
+		return fmt.Errorf("export: unsupported extension on %q (use .md or .html)", path)
+	}
+	data = []byte(redactForStorage(ctx, string(data)))
+	return os.WriteFile(path, data, 0o644)
+}
+
+// slashCommand dispatches a /command line; it reports quit=true for /quit.
+func (st *chatState) slashCommand(ctx context.Context, line string) (quit bool, err error) {
+	fields := strings.Fields(line)
+	cmd, args := fields[0], fields[1:]
+	switch cmd {
+	case "/quit", "/exit":
+		return true, nil
+	case "/help":
+		fmt.Print(`/fork <name> [turn]   fork the conversation at a turn (default: current) into a new branch
+/branch list          list branches with turn counts
+/switch <name>        switch to another branch
+/reset                clear the active branch's history
+/model [id]           show or switch the model
+/system [text]        show or replace the system prompt
+/save                 persist the session now
+/tokens               show token usage and estimated cost for this process
+/paste-image          attach an image from the clipboard to the next message
+/prompt <query>       recall a saved prompt by name or fuzzy match and send it
+/edit                 compose a multi-line prompt in $EDITOR (previous draft preloaded)
+/export <path>        export the transcript to Markdown (.md) or HTML (.html)
+/quit                 save and exit
+`)
+		return false, nil
+	case "/reset":
+		st.sess.Branches[st.sess.Branch] = nil
+		fmt.Println("history cleared")
+		return false, st.store.Save(ctx, st.sess)
+	case "/model":
+		if len(args) == 0 {
+			fmt.Println(st.client.ModelID)
+			return false, nil
+		}
+		st.client.ModelID = args[0]
+		fmt.Printf("model set to %s\n", st.client.ModelID)
+		return false, nil
+	case "/system":
+		if len(args) == 0 {
+			if st.system == "" {
+				fmt.Println("(no system prompt)")
+			} else {
+				fmt.Println(st.system)
+			}
+			return false, nil
+		}
+		st.system = strings.TrimSpace(strings.TrimPrefix(line, "/system"))
+		fmt.Println("system prompt updated")
+		return false, nil
+	case "/save":
+		if err := st.store.Save(ctx, st.sess); err != nil {
+			return false, err
+		}
+		fmt.Printf("saved session %s\n", st.sess.ID)
+		return false, nil
+	case "/prompt":
+		if len(args) == 0 {
+			return false, fmt.Errorf("usage: /prompt <name or fuzzy query>")
+		}
+		store, err := openPromptStore("")
+		if err != nil {
+			return false, err
+		}
+		query := strings.Join(args, " ")
+		entry, ok := store.get(query)
+		if !ok {
+			matches := store.search(query)
+			switch len(matches) {
+			case 0:
+				return false, fmt.Errorf("no saved prompt matches %q (see the prompts command)", query)
+			case 1:
+				entry = matches[0]
+			default:
+				fmt.Println("multiple matches:")
+				for _, m := range matches {
+					fmt.Printf("  %-20s %s\n", m.Name, snippet(m.Text, 60))
+				}
+				return false, nil
+			}
+		}
+		store.markUsed(entry.Name)
+		fmt.Printf("recalled %s\n", entry.Name)
+		return false, st.sendTurn(ctx, entry.textFor(st.client.ModelID))
+	case "/paste-image":
+		data, err := readClipboardImage()
+		if err != nil {
+			return false, err
+		}
+		st.images = append(st.images, claude.Image{Format: "png", Data: data})
+		fmt.Printf("image attached (%d bytes); it will be sent with your next message\n", len(data))
+		return false, nil
+	case "/edit":
+		text, err := st.editDraft()
+		if err != nil {
+			return false, err
+		}
+		if text == "" {
+			fmt.Println("empty draft, nothing sent")
+			return false, nil
+		}
+		return false, st.sendTurn(ctx, text)
+	case "/export":
+		if len(args) != 1 {
+			return false, fmt.Errorf("usage: /export <file.md|file.html>")
+		}
+		if err := exportTranscript(ctx, st.sess, args[0]); err != nil {
+			return false, err
+		}
+		fmt.Printf("exported to %s\n", args[0])
+		return false, nil
+	case "/tokens":
+		fmt.Printf("input tokens:  %d\noutput tokens: %d\n", st.usage.InputTokens, st.usage.OutputTokens)
+		if cost, ok := claude.EstimateCost(st.client.ModelID, st.usage); ok {
+			fmt.Printf("estimated cost: $%.4f\n", cost)
+		}
+		return false, nil
+	case "/fork":
+		if len(args) == 0 {
+			return false, fmt.Errorf("usage: /fork <name> [turn]")
+		}
+		upTo := -1
+		if len(args) > 1 {
+			if upTo, err = strconv.Atoi(args[1]); err != nil {
+				return false, fmt.Errorf("bad turn index %q", args[1])
+			}
+		}
+		if err := st.sess.Fork(args[0], upTo); err != nil {
+			return false, err
+		}
+		fmt.Printf("forked to branch %s at turn %d\n", st.sess.Branch, len(st.sess.Turns()))
+		return false, st.store.Save(ctx, st.sess)
+	case "/branch":
+		if len(args) == 0 || args[0] != "list" {
+			return false, fmt.Errorf("usage: /branch list")
+		}
+		for _, name := range st.sess.BranchNames() {
+			marker := " "
+			if name == st.sess.Branch {
+				marker = "*"
+			}
+			fmt.Printf("%s %-20s %d turns\n", marker, name, len(st.sess.Branches[name]))
+		}
+		return false, nil
+	case "/switch":
+		if len(args) != 1 {
+			return false, fmt.Errorf("usage: /switch <name>")
+		}
+		if err := st.sess.Switch(args[0]); err != nil {
+			return false, err
+		}
+		fmt.Printf("on branch %s (%d turns)\n", st.sess.Branch, len(st.sess.Turns()))
+		return false, st.store.Save(ctx, st.sess)
+	default:
+		return false, fmt.Errorf("unknown command %s (try /help)", cmd)
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/session"
+)
+
+// completionModels are the model IDs offered when completing -model flags; a
+// starting set, since the full catalogue needs a control-plane call.
+var completionModels = []string{
+	"anthropic.claude-3-haiku-20240307-v1:0",
+	"anthropic.claude-3-5-haiku-20241022-v1:0",
+	"anthropic.claude-3-5-sonnet-20241022-v2:0",
+	"anthropic.claude-3-7-sonnet-20250219-v1:0",
+	"anthropic.claude-3-opus-20240229-v1:0",
+	"anthropic.claude-sonnet-4-20250514-v1:0",
+	"amazon.nova-micro-v1:0",
+	"amazon.nova-lite-v1:0",
+	"amazon.nova-pro-v1:0",
+	"meta.llama3-70b-instruct-v1:0",
+	"mistral.mistral-large-2402-v1:0",
+	claude.DefaultModelID,
+}
+
+// runCompletion prints a completion script for the requested shell. The
+// scripts call back into "claude-bedrock-cli __complete <kind>" so model
+// IDs, saved prompt names, and session IDs complete from live data.
+func runCompletion(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("completion", flag.ExitOnError)
+	fs.Parse(args)
+	switch fs.Arg(0) {
+	case "bash":
+		fmt.Print(bashCompletion)
+	case "zsh":
+		// zsh runs the bash script through its bash compatibility layer.
+		fmt.Print("autoload -U +X bashcompinit && bashcompinit\n" + bashCompletion)
+	case "fish":
+		fmt.Print(fishCompletion)
+	default:
+		return fmt.Errorf("completion: usage: completion bash|zsh|fish")
+	}
+	return nil
+}
+
+// runCompleteHook serves the dynamic word lists the completion scripts ask
+// for; it prints one candidate per line and stays silent on any error, since
+// a completion callback must never break the shell.
+func runCompleteHook(ctx context.Context, args []string) error {
+	kind := ""
+	if len(args) > 0 {
+		kind = args[0]
+	}
+	switch kind {
+	case "commands":
+		for _, name := range commandNames() {
+			fmt.Println(name)
+		}
+	case "models":
+		for _, id := range completionModels {
+			fmt.Println(id)
+		}
+	case "prompts":
+		store, err := openPromptStore("")
+		if err != nil {
+			return nil
+		}
+		for _, entry := range store.Entries {
+			fmt.Println(entry.Name)
+		}
+	case "sessions":
+		store, err := session.OpenStore("")
+		if err != nil {
+			return nil
+		}
+		ids, err := store.List()
+		if err != nil {
+			return nil
+		}
+		for _, id := range ids {
+			fmt.Println(id)
+		}
+	}
+	return nil
+}
+
+const bashCompletion = `_claude_bedrock_cli() {
+    local cur prev
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+    case "$prev" in
+        -model|--model|-critic-model|--critic-model)
+            COMPREPLY=($(compgen -W "$(claude-bedrock-cli __complete models 2>/dev/null)" -- "$cur"))
+            return ;;
+        -resume|--resume)
+            COMPREPLY=($(compgen -W "$(claude-bedrock-cli __complete sessions 2>/dev/null)" -- "$cur"))
+            return ;;
+    esac
+    if [ "$COMP_CWORD" -eq 1 ]; then
+        COMPREPLY=($(compgen -W "$(claude-bedrock-cli __complete commands 2>/dev/null)" -- "$cur"))
+        return
+    fi
+    case "${COMP_WORDS[1]}" in
+        completion)
+            COMPREPLY=($(compgen -W "bash zsh fish" -- "$cur")) ;;
+        profiles)
+            COMPREPLY=($(compgen -W "list create delete" -- "$cur")) ;;
+        prompts)
+            COMPREPLY=($(compgen -W "list save delete run $(claude-bedrock-cli __complete prompts 2>/dev/null)" -- "$cur")) ;;
+        sessions)
+            COMPREPLY=($(compgen -W "list delete purge search export import $(claude-bedrock-cli __complete sessions 2>/dev/null)" -- "$cur")) ;;
+    esac
+}
+complete -F _claude_bedrock_cli claude-bedrock-cli
+`
+
+const fishCompletion = `complete -c claude-bedrock-cli -f
+complete -c claude-bedrock-cli -n "__fish_use_subcommand" -a "(claude-bedrock-cli __complete commands 2>/dev/null)"
+complete -c claude-bedrock-cli -n "__fish_seen_subcommand_from completion" -a "bash zsh fish"
+complete -c claude-bedrock-cli -n "__fish_seen_subcommand_from profiles" -a "list create delete"
+complete -c claude-bedrock-cli -n "__fish_seen_subcommand_from prompts" -a "list save delete run (claude-bedrock-cli __complete prompts 2>/dev/null)"
+complete -c claude-bedrock-cli -n "__fish_seen_subcommand_from sessions" -a "list delete purge search export import (claude-bedrock-cli __complete sessions 2>/dev/null)"
+complete -c claude-bedrock-cli -l model -a "(claude-bedrock-cli __complete models 2>/dev/null)"
+complete -c claude-bedrock-cli -l resume -a "(claude-bedrock-cli __complete sessions 2>/dev/null)"
+`
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+)
+
+// runDiffModels runs one prompt on two model IDs and reports a word-level
+// diff of the answers plus a usage and latency comparison, for sizing up
+// a model migration.
+func runDiffModels(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("diff-models", flag.ExitOnError)
+	modelA := fs.String("a", "", "first Bedrock model ID")
+	modelB := fs.String("b", "", "second Bedrock model ID")
+	region := fs.String("region", "", "AWS region")
+	system := fs.String("system", "", "system prompt")
+	var files fileFlags
+	fs.Var(&files, "file", "context document to include with the prompt; repeatable, accepts globs")
+	maxTokens := fs.Int("max-tokens", 4096, "max output tokens")
+	seed := fs.Int64("seed", -1, "sampling seed for models that support it (-1: unset)")
+	full := fs.Bool("full", false, "print both answers in full before the diff")
+	fs.Parse(args)
+
+	if *modelA == "" || *modelB == "" {
+		return fmt.Errorf("diff-models: usage: diff-models -a <model> -b <model> [options] \"prompt\"")
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("diff-models: prompt required")
+	}
+	prompt := strings.Join(fs.Args(), " ")
+	if len(files) > 0 {
+		docs, err := readDocuments(files)
+		if err != nil {
+			return err
+		}
+		prompt = docs + "\n" + prompt
+	}
+
+	client, err := claude.NewClient(ctx, *region, *modelA)
+	if err != nil {
+		return err
+	}
+	req := claude.Request{
+		System:    *system,
+		Messages:  []claude.Message{claude.UserMessage(prompt)},
+		MaxTokens: int32(*maxTokens),
+	}
+	if *seed >= 0 {
+		req.Seed = seed
+	}
+
+	type result struct {
+		text    string
+		usage   claude.Usage
+		latency time.Duration
+		err     error
+	}
+	run := func(c *claude.Client) result {
+		start := time.Now()
+		text, usage, err := c.ConverseUsage(ctx, req)
+		return result{text: strings.TrimSpace(text), usage: usage, latency: time.Since(start), err: err}
+	}
+	resB := make(chan result, 1)
+	go func() { resB <- run(client.WithModel(*modelB)) }()
+	a := run(client)
+	b := <-resB
+	if a.err != nil {
+		return fmt.Errorf("diff-models: %s: %w", *modelA, a.err)
+	}
+	if b.err != nil {
+		return fmt.Errorf("diff-models: %s: %w", *modelB, b.err)
+	}
+
+	if *full {
+		fmt.Printf("=== %s ===\n%s\n\n=== %s ===\n%s\n\n", *modelA, a.text, *modelB, b.text)
+	}
+	fmt.Println("=== diff (a [-removed-], b {+added+}) ===")
+	fmt.Println(wordDiff(a.text, b.text))
+
+	fmt.Fprintf(os.Stderr, "\n%-55s %10s %10s %10s\n", "model", "in", "out", "latency")
+	fmt.Fprintf(os.Stderr, "%-55s %10d %10d %9.1fs\n", *modelA, a.usage.InputTokens, a.usage.OutputTokens, a.latency.Seconds())
+	fmt.Fprintf(os.Stderr, "%-55s %10d %10d %9.1fs\n", *modelB, b.usage.InputTokens, b.usage.OutputTokens, b.latency.Seconds())
+	return nil
+}
+
+// wordDiff renders a word-level diff in wdiff notation: words only in a
+// appear as [-...-], words only in b as {+...+}.
+func wordDiff(a, b string) string {
+	aw := strings.Fields(a)
+	bw := strings.Fields(b)
+
+	// Longest common subsequence over words.
+	lcs := make([][]int, len(aw)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(bw)+1)
+	}
+	for i := len(aw) - 1; i >= 0; i-- {
+		for j := len(bw) - 1; j >= 0; j-- {
+			if aw[i] == bw[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var out []string
+	flush := func(words []string, opener, closer string) {
+		if len(words) > 0 {
+			out = append(out, opener+strings.Join(words, " ")+closer)
+		}
+	}
+	var del, add []string
+	i, j := 0, 0
+	for i < len(aw) && j < len(bw) {
+		switch {
+		case aw[i] == bw[j]:
+			flush(del, "[-", "-]")
+			flush(add, "{+", "+}")
+			del, add = nil, nil
+			out = append(out, aw[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			del = append(del, aw[i])
+			i++
+		default:
+			add = append(add, bw[j])
+			j++
+		}
+	}
+	del = append(del, aw[i:]...)
+	add = append(add, bw[j:]...)
+	flush(del, "[-", "-]")
+	flush(add, "{+", "+}")
+	return strings.Join(out, " ")
+}
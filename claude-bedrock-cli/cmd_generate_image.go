@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/imagegen"
+)
+
+// rewriteSystem turns a terse idea into the kind of dense, concrete prompt
+// image models respond to.
+const rewriteSystem = "You rewrite short image ideas into detailed image-generation prompts. Expand the idea with concrete subject, composition, lighting, style, and mood details. Reply with only the rewritten prompt, under 80 words."
+
+// runGenerateImage generates images with a Bedrock image model, optionally
+// having Claude rewrite the prompt first.
+func runGenerateImage(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("generate-image", flag.ExitOnError)
+	model := fs.String("model", "", "Bedrock image model ID (default: "+imagegen.DefaultModelID+")")
+	region := fs.String("region", "", "AWS region")
+	out := fs.String("out", "image.png", "output PNG path; with -count > 1, numbered -1, -2, ... before the extension")
+	width := fs.Int("width", 0, "image width in pixels (0: model default)")
+	height := fs.Int("height", 0, "image height in pixels (0: model default)")
+	seed := fs.Int64("seed", -1, "generation seed for repeatable output (-1: unset)")
+	count := fs.Int("count", 1, "number of images to generate")
+	negative := fs.String("negative", "", "negative prompt: what the image should avoid")
+	rewrite := fs.Bool("rewrite", false, "have Claude rewrite the prompt with composition and style detail first")
+	rewriteModel := fs.String("rewrite-model", "", "Claude model for -rewrite (default: a cheap Haiku model)")
+	fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		return fmt.Errorf("generate-image: usage: generate-image [options] \"prompt\"")
+	}
+	prompt := strings.Join(fs.Args(), " ")
+
+	if *rewrite {
+		if *rewriteModel == "" {
+			*rewriteModel = "anthropic.claude-3-haiku-20240307-v1:0"
+		}
+		writer, err := claude.NewClient(ctx, *region, *rewriteModel)
+		if err != nil {
+			return err
+		}
+		rewritten, err := writer.Converse(ctx, claude.Request{
+			System:    rewriteSystem,
+			Messages:  []claude.Message{claude.UserMessage(prompt)},
+			MaxTokens: 256,
+		})
+		if err != nil {
+			return fmt.Errorf("generate-image: rewriting prompt: %w", err)
+		}
+		prompt = strings.TrimSpace(rewritten)
+		note("generate-image: rewritten prompt: %s", prompt)
+	}
+
+	client, err := imagegen.NewClient(ctx, *region, *model)
+	if err != nil {
+		return err
+	}
+	images, err := client.Generate(ctx, prompt, imagegen.Options{
+		NegativePrompt: *negative,
+		Width:          *width,
+		Height:         *height,
+		Seed:           *seed,
+		Count:          *count,
+	})
+	if err != nil {
+		return err
+	}
+	for i, data := range images {
+		path := *out
+		if len(images) > 1 {
+			ext := ".png"
+			if dot := strings.LastIndex(path, "."); dot > 0 {
+				path, ext = path[:dot], path[dot:]
+			}
+			path = fmt.Sprintf("%s-%d%s", path, i+1, ext)
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return err
+		}
+		note("generate-image: wrote %s (%d bytes)", path, len(data))
+	}
+	return nil
+}
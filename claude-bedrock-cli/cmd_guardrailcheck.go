@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+)
+
+// runGuardrailCheck validates arbitrary text against a guardrail without a
+// model invocation, printing the structured assessment. It exits non-zero
+// when the guardrail intervenes so scripts can gate on the result.
+func runGuardrailCheck(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("guardrail-check", flag.ExitOnError)
+	id := fs.String("id", "", "guardrail identifier or ARN")
+	version := fs.String("guardrail-version", "DRAFT", "guardrail version")
+	source := fs.String("source", "input", "which side's policies to apply: input or output")
+	file := fs.String("file", "", "text to check (default: the arguments, or stdin)")
+	region := fs.String("region", "", "AWS region")
+	jsonOut := fs.Bool("json", false, "print the full assessment as JSON")
+	fs.Parse(args)
+
+	if *id == "" {
+		return fmt.Errorf("guardrail-check: -id is required")
+	}
+	guardSource := strings.ToUpper(*source)
+	if guardSource != "INPUT" && guardSource != "OUTPUT" {
+		return fmt.Errorf("guardrail-check: -source must be input or output, got %q", *source)
+	}
+	text := strings.Join(fs.Args(), " ")
+	if text == "" {
+		var err error
+		if text, err = readInput(*file); err != nil {
+			return err
+		}
+	}
+
+	client, err := claude.NewClient(ctx, *region, "")
+	if err != nil {
+		return err
+	}
+	result, err := client.ApplyGuardrail(ctx, *id, *version, guardSource, text)
+	if err != nil {
+		return err
+	}
+
+	if *jsonOut {
+		if err := json.NewEncoder(os.Stdout).Encode(result); err != nil {
+			return err
+		}
+	} else if result.Intervened {
+		fmt.Println("action: intervened")
+		if result.OutputText != "" {
+			fmt.Println(result.OutputText)
+		}
+	} else {
+		fmt.Println("action: none")
+	}
+	if result.Intervened {
+		os.Exit(1)
+	}
+	return nil
+}
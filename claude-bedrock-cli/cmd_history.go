@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/audit"
+)
+
+func runHistory(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	n := fs.Int("n", 20, "number of invocations to show")
+	model := fs.String("model", "", "filter by model ID substring")
+	since := fs.Duration("since", 0, "only show invocations newer than this (e.g. 24h)")
+	db := fs.String("db", "", "audit database path (default: ~/.claude-bedrock-cli/audit.db)")
+	fs.Parse(args)
+
+	alog, err := audit.Open(*db)
+	if err != nil {
+		return err
+	}
+	defer alog.Close()
+
+	var lower time.Time
+	if *since > 0 {
+		lower = time.Now().Add(-*since)
+	}
+	recs, err := alog.Query(ctx, *n, *model, lower)
+	if err != nil {
+		return err
+	}
+	if len(recs) == 0 {
+		fmt.Println("no audited invocations (set CLAUDE_CLI_AUDIT=1 to enable auditing)")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 2, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tCOMMAND\tMODEL\tIN\tOUT\tCOST\tLATENCY\tREQUEST-ID\tPROMPT-SHA")
+	for _, rec := range recs {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t$%.4f\t%dms\t%s\t%.12s\n",
+			rec.Time.Local().Format("2006-01-02 15:04:05"), rec.Command, rec.Model,
+			rec.InputTokens, rec.OutputTokens, rec.CostUSD, rec.LatencyMs, rec.RequestID, rec.PromptSHA256)
+	}
+	return w.Flush()
+}
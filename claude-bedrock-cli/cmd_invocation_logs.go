@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/bedrock"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+)
+
+// runInvocationLogs looks up the server-side Bedrock invocation log entry for
+// a request ID captured client-side (see the history subcommand), tying the
+// local audit record to the account's invocation logging destination.
+func runInvocationLogs(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("invocation-logs", flag.ExitOnError)
+	region := fs.String("region", "", "AWS region")
+	window := fs.Duration("window", 7*24*time.Hour, "how far back to search the log group")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("invocation-logs: usage: invocation-logs [options] <request-id>")
+	}
+	requestID := fs.Arg(0)
+
+	var opts []func(*awsconfig.LoadOptions) error
+	if *region != "" {
+		opts = append(opts, awsconfig.WithRegion(*region))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("loading AWS config: %w", err)
+	}
+
+	logging, err := bedrock.NewFromConfig(cfg).GetModelInvocationLoggingConfiguration(ctx,
+		&bedrock.GetModelInvocationLoggingConfigurationInput{})
+	if err != nil {
+		return fmt.Errorf("reading invocation logging configuration: %w", err)
+	}
+	if logging.LoggingConfig == nil {
+		return fmt.Errorf("model invocation logging is not enabled in this account/region")
+	}
+
+	if s3 := logging.LoggingConfig.S3Config; s3 != nil && s3.BucketName != nil {
+		prefix := aws.ToString(s3.KeyPrefix)
+		fmt.Printf("S3 destination: s3://%s/%s (search objects for request ID %s)\n",
+			aws.ToString(s3.BucketName), prefix, requestID)
+	}
+
+	cw := logging.LoggingConfig.CloudWatchConfig
+	if cw == nil || cw.LogGroupName == nil {
+		return nil
+	}
+	group := aws.ToString(cw.LogGroupName)
+	fmt.Printf("searching CloudWatch log group %s for request ID %s...\n", group, requestID)
+
+	logs := cloudwatchlogs.NewFromConfig(cfg)
+	paginator := cloudwatchlogs.NewFilterLogEventsPaginator(logs, &cloudwatchlogs.FilterLogEventsInput{
+		LogGroupName:  aws.String(group),
+		FilterPattern: aws.String(fmt.Sprintf("%q", requestID)),
+		StartTime:     aws.Int64(time.Now().Add(-*window).UnixMilli()),
+	})
+	found := false
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("searching %s: %w", group, err)
+		}
+		for _, event := range page.Events {
+			found = true
+			fmt.Printf("--- %s ---\n%s\n",
+				time.UnixMilli(aws.ToInt64(event.Timestamp)).Format(time.RFC3339),
+				aws.ToString(event.Message))
+		}
+	}
+	if !found {
+		fmt.Printf("no log entry found for %s in the last %s (logs can lag by a few minutes)\n", requestID, *window)
+	}
+	return nil
+}
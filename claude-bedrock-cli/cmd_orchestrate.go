@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/agent"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+)
+
+// builtinRoles are the role specializations the orchestrate command knows
+// about, run in this order. The researcher optionally carries tools; the
+// writer's output is the consolidated answer.
+var builtinRoles = []agent.Role{
+	{
+		Name:   "researcher",
+		System: "You are the researcher. Gather the facts, figures, and sources the task needs. Be thorough and flag anything you are unsure about.",
+	},
+	{
+		Name:   "critic",
+		System: "You are the critic. Check the earlier turns for errors, gaps, and unsupported claims. List concrete problems and what would fix them; do not rewrite the answer yourself.",
+	},
+	{
+		Name:   "writer",
+		System: "You are the writer. Produce the final answer to the task, incorporating the researcher's findings and addressing every point the critic raised.",
+	},
+}
+
+// runOrchestrate answers a task with a relay of role-specialized agents
+// (researcher, critic, writer), printing the turn-by-turn trace on request.
+func runOrchestrate(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("orchestrate", flag.ExitOnError)
+	model := fs.String("model", "", "Bedrock model ID for every role")
+	region := fs.String("region", "", "AWS region")
+	roleList := fs.String("roles", "researcher,critic,writer", "comma-separated roles to run, in order")
+	rounds := fs.Int("rounds", 1, "passes over the roles; extra rounds feed late critique back to early roles")
+	maxTokens := fs.Int("max-tokens", 4096, "max output tokens per turn")
+	tools := fs.Bool("tools", false, "give the researcher the calculator and shell tools")
+	yes := fs.Bool("yes", false, "auto-approve tool calls that would ask for confirmation")
+	verbose := fs.Bool("verbose", false, "print each turn and its token usage to stderr")
+	fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		return fmt.Errorf("orchestrate: usage: orchestrate [options] <task>")
+	}
+	task := strings.Join(fs.Args(), " ")
+
+	var roles []agent.Role
+	for _, name := range strings.Split(*roleList, ",") {
+		name = strings.TrimSpace(name)
+		found := false
+		for _, r := range builtinRoles {
+			if r.Name == name {
+				roles = append(roles, r)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("orchestrate: unknown role %q (have researcher, critic, writer)", name)
+		}
+	}
+	if *tools {
+		for i := range roles {
+			if roles[i].Name == "researcher" {
+				roles[i].Tools = []agent.Tool{agent.Calculator{}, agent.Shell{}}
+			}
+		}
+	}
+
+	client, err := claude.NewClient(ctx, *region, *model)
+	if err != nil {
+		return err
+	}
+	o := &agent.Orchestrator{
+		Client:    client,
+		Roles:     roles,
+		Rounds:    *rounds,
+		MaxTokens: int32(*maxTokens),
+		Policy:    agent.DefaultPolicy(),
+		Confirm:   confirmOnTerminal,
+	}
+	if *yes {
+		o.Confirm = func(string, string) bool { return true }
+	}
+	if *verbose {
+		o.OnTurn = func(t agent.Turn) {
+			fmt.Fprintf(os.Stderr, "--- round %d, %s (%d in, %d out) ---\n%s\n",
+				t.Round, t.Role, t.Usage.InputTokens, t.Usage.OutputTokens, t.Output)
+		}
+	}
+
+	answer, trace, err := o.Run(ctx, task)
+	if err != nil {
+		return err
+	}
+	fmt.Println(answer)
+	if *verbose {
+		var in, out int32
+		for _, t := range trace {
+			in += t.Usage.InputTokens
+			out += t.Usage.OutputTokens
+		}
+		fmt.Fprintf(os.Stderr, "total: %d turns, %d in, %d out\n", len(trace), in, out)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/patch"
+)
+
+const patchSystem = `You are a careful coding assistant. Respond with ONLY a unified diff
+(--- a/path, +++ b/path, @@ hunks) implementing the requested change to the
+provided files. Include 3 lines of context per hunk. No prose, no code fences.`
+
+// runPatch asks the model for code changes as a unified diff, walks the
+// hunks with per-hunk approval, and applies accepted ones with .bak backups.
+func runPatch(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("patch", flag.ExitOnError)
+	model := fs.String("model", "", "Bedrock model ID")
+	region := fs.String("region", "", "AWS region")
+	maxTokens := fs.Int("max-tokens", 8192, "max output tokens")
+	var files fileList
+	fs.Var(&files, "file", "file to expose to the model (repeatable)")
+	yes := fs.Bool("yes", false, "apply all hunks without asking")
+	fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		return fmt.Errorf("patch: usage: patch -file <path> [options] <change request>")
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("patch: at least one -file is required")
+	}
+	request := strings.Join(fs.Args(), " ")
+
+	var prompt strings.Builder
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&prompt, "<file path=%q>\n%s\n</file>\n", path, data)
+	}
+	prompt.WriteString("\nChange request: " + request)
+
+	client, err := claude.NewClient(ctx, *region, *model)
+	if err != nil {
+		return err
+	}
+	response, err := client.Converse(ctx, claude.Request{
+		System:    patchSystem,
+		Messages:  []claude.Message{claude.UserMessage(prompt.String())},
+		MaxTokens: int32(*maxTokens),
+	})
+	if err != nil {
+		return err
+	}
+
+	diffs, err := patch.Parse(response)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, response)
+		return err
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	applyAll := *yes
+	applied, skipped := 0, 0
+	for _, fd := range diffs {
+		data, err := os.ReadFile(fd.Path)
+		if err != nil {
+			return fmt.Errorf("patch: %s: %w", fd.Path, err)
+		}
+		content := string(data)
+		changed := false
+		for _, hunk := range fd.Hunks {
+			fmt.Printf("\n%s\n%s\n", fd.Path, hunk)
+			if !applyAll {
+				switch askHunk(reader) {
+				case "a":
+					applyAll = true
+				case "y":
+				default:
+					skipped++
+					continue
+				}
+			}
+			next, err := patch.ApplyHunk(content, hunk)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "skipping: %v\n", err)
+				skipped++
+				continue
+			}
+			content = next
+			changed = true
+			applied++
+		}
+		if !changed {
+			continue
+		}
+		if err := os.WriteFile(fd.Path+".bak", data, 0o644); err != nil {
+			return err
+		}
+		if err := os.WriteFile(fd.Path, []byte(content), 0o644); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("\napplied %d hunk(s), skipped %d (originals saved as .bak)\n", applied, skipped)
+	return nil
+}
+
+func askHunk(reader *bufio.Reader) string {
+	fmt.Fprint(os.Stderr, "apply this hunk? [y/N/a] ")
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "n"
+	}
+	return strings.ToLower(strings.TrimSpace(line))
+}
+
+// fileList collects repeated -file flags.
+type fileList []string
+
+func (f *fileList) String() string { return strings.Join(*f, ",") }
+func (f *fileList) Set(v string) error {
+	*f = append(*f, v)
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/pipeline"
+)
+
+// runPipeline executes a YAML-defined prompt chain against an input
+// document.
+func runPipeline(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("pipeline", flag.ExitOnError)
+	def := fs.String("f", "", "pipeline definition YAML file")
+	file := fs.String("file", "", "input document (default: stdin)")
+	model := fs.String("model", "", "default Bedrock model ID for stages without one")
+	region := fs.String("region", "", "AWS region")
+	verbose := fs.Bool("verbose", false, "print each stage's output to stderr as it completes")
+	noCache := fs.Bool("no-cache", false, "re-execute every stage instead of reusing cached outputs")
+	cacheDir := fs.String("cache-dir", "", "stage cache directory (default: the user cache dir)")
+	fs.Parse(args)
+
+	if *def == "" {
+		return fmt.Errorf("pipeline: usage: pipeline -f <definition.yaml> [-file input]")
+	}
+	p, err := pipeline.Load(*def)
+	if err != nil {
+		return err
+	}
+	if !*noCache {
+		if p.Cache, err = pipeline.OpenCache(*cacheDir); err != nil {
+			return err
+		}
+	}
+	input, err := readInput(*file)
+	if err != nil {
+		return err
+	}
+	client, err := claude.NewClient(ctx, *region, *model)
+	if err != nil {
+		return err
+	}
+
+	var onStage func(stage, output string)
+	if *verbose {
+		onStage = func(stage, output string) {
+			fmt.Fprintf(os.Stderr, "--- %s ---\n%s\n", stage, output)
+		}
+	}
+	_, result, err := p.Run(ctx, client, input, onStage)
+	if err != nil {
+		return err
+	}
+	fmt.Println(result)
+	return nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/bedrock"
+	bedrocktypes "github.com/aws/aws-sdk-go-v2/service/bedrock/types"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+)
+
+// runProfiles manages application inference profiles: create provisions one
+// on the Bedrock control plane and records its ARN locally, after which
+// -model on any command accepts the profile's name.
+func runProfiles(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("profiles", flag.ExitOnError)
+	region := fs.String("region", "", "AWS region")
+	source := fs.String("source", "", "with create, the source: a model ID/ARN or a cross-region inference profile ARN for multi-region routing")
+	description := fs.String("description", "", "with create, a description stored on the profile")
+	var profileTags tagFlags
+	fs.Var(&profileTags, "tag", "with create, a key=value tag on the profile for cost allocation; repeatable")
+	fs.Parse(args)
+
+	switch {
+	case fs.NArg() == 0 || fs.Arg(0) == "list":
+		profiles, err := claude.LoadProfiles()
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "NAME\tSOURCE\tARN\tCREATED")
+		for _, p := range profiles {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", p.Name, p.Source, p.ARN, p.Created.Format("2006-01-02"))
+		}
+		return tw.Flush()
+	case fs.Arg(0) == "create":
+		if fs.NArg() != 2 || *source == "" {
+			return fmt.Errorf("profiles: usage: profiles create -source <model-or-arn> [-tag k=v] [-description text] <name>")
+		}
+		name := fs.Arg(1)
+
+		var opts []func(*awsconfig.LoadOptions) error
+		if *region != "" {
+			opts = append(opts, awsconfig.WithRegion(*region))
+		}
+		cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+		if err != nil {
+			return fmt.Errorf("loading AWS config: %w", err)
+		}
+		// A bare model ID names a foundation model in the current region; an
+		// ARN (foundation model or cross-region profile) passes through.
+		sourceARN := *source
+		if !strings.HasPrefix(sourceARN, "arn:") {
+			sourceARN = fmt.Sprintf("arn:aws:bedrock:%s::foundation-model/%s", cfg.Region, sourceARN)
+		}
+
+		input := &bedrock.CreateInferenceProfileInput{
+			InferenceProfileName: aws.String(name),
+			ModelSource:          &bedrocktypes.InferenceProfileModelSourceMemberCopyFrom{Value: sourceARN},
+		}
+		if *description != "" {
+			input.Description = description
+		}
+		for _, key := range sortedTagKeys(profileTags) {
+			input.Tags = append(input.Tags, bedrocktypes.Tag{
+				Key:   aws.String(key),
+				Value: aws.String(profileTags[key]),
+			})
+		}
+		out, err := bedrock.NewFromConfig(cfg).CreateInferenceProfile(ctx, input)
+		if err != nil {
+			return fmt.Errorf("profiles: creating %s: %w", name, err)
+		}
+		arn := aws.ToString(out.InferenceProfileArn)
+		if err := claude.SaveProfile(claude.Profile{
+			Name:    name,
+			ARN:     arn,
+			Source:  *source,
+			Created: time.Now().UTC(),
+		}); err != nil {
+			return err
+		}
+		fmt.Println(arn)
+		note("profiles: recorded %s; invoke it with -model %s", name, name)
+		return nil
+	case fs.Arg(0) == "delete":
+		if fs.NArg() != 2 {
+			return fmt.Errorf("profiles: usage: profiles delete <name>")
+		}
+		existed, err := claude.DeleteProfile(fs.Arg(1))
+		if err != nil {
+			return err
+		}
+		if !existed {
+			return fmt.Errorf("profiles: no profile named %q", fs.Arg(1))
+		}
+		note("profiles: removed local record; the Bedrock-side profile still exists")
+		return nil
+	default:
+		return fmt.Errorf("profiles: unknown action %q (want list, create, or delete)", fs.Arg(0))
+	}
+}
+
+// sortedTagKeys orders tag keys so the control-plane request is stable.
+func sortedTagKeys(tags map[string]string) []string {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// runPrompts manages the saved prompt library: listing, saving, deleting,
+// and running prompts by name so they do not have to be re-pasted.
+func runPrompts(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("prompts", flag.ExitOnError)
+	storePath := fs.String("store", "", "prompt library file (default: ~/.claude-bedrock-cli/prompts.json)")
+	promptTags := fs.String("tags", "", "comma-separated tags recorded with a saved prompt")
+	file := fs.String("file", "", "with save, read the prompt text from this file instead of the arguments")
+	model := fs.String("model", "", "with run, the Bedrock model ID; also selects the matching prompt variant")
+	var variants tagFlags
+	fs.Var(&variants, "variant", "with save, a model=text variant; repeatable, @path reads the text from a file")
+	fs.Parse(args)
+
+	store, err := openPromptStore(*storePath)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case fs.NArg() == 0 || fs.Arg(0) == "list":
+		query := ""
+		if fs.NArg() > 1 {
+			query = fs.Arg(1)
+		}
+		entries := store.Entries
+		if query != "" {
+			entries = store.search(query)
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "NAME\tTAGS\tVARIANTS\tUSES\tPROMPT")
+		for _, e := range entries {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", e.Name, strings.Join(e.Tags, ","),
+				strings.Join(variantKeys(e), ","), e.Uses, snippet(e.Text, 60))
+		}
+		return tw.Flush()
+	case fs.Arg(0) == "save":
+		if fs.NArg() < 2 {
+			return fmt.Errorf("prompts: usage: prompts save [-tags a,b] <name> \"prompt text\" (or -file)")
+		}
+		name := fs.Arg(1)
+		text := strings.Join(fs.Args()[2:], " ")
+		if *file != "" {
+			if text != "" {
+				return fmt.Errorf("prompts: give the prompt as arguments or -file, not both")
+			}
+			if text, err = readInput(*file); err != nil {
+				return err
+			}
+		}
+		if strings.TrimSpace(text) == "" {
+			return fmt.Errorf("prompts: empty prompt text")
+		}
+		resolved, err := resolveVariants(variants)
+		if err != nil {
+			return err
+		}
+		store.put(promptEntry{
+			Name:     name,
+			Text:     text,
+			Tags:     splitPatterns(*promptTags),
+			Variants: resolved,
+			Created:  time.Now().UTC(),
+		})
+		return store.save()
+	case fs.Arg(0) == "delete":
+		if fs.NArg() != 2 {
+			return fmt.Errorf("prompts: usage: prompts delete <name>")
+		}
+		if !store.delete(fs.Arg(1)) {
+			return fmt.Errorf("prompts: no prompt named %q", fs.Arg(1))
+		}
+		return store.save()
+	case fs.Arg(0) == "run":
+		if fs.NArg() < 2 {
+			return fmt.Errorf("prompts: usage: prompts run <name> [run options]")
+		}
+		entry, ok := store.get(fs.Arg(1))
+		if !ok {
+			matches := store.search(fs.Arg(1))
+			if len(matches) != 1 {
+				return fmt.Errorf("prompts: no prompt named %q", fs.Arg(1))
+			}
+			entry = matches[0]
+		}
+		store.markUsed(entry.Name)
+		// Delegate to run so saved prompts get its full feature set
+		// (-best-of, post-processing, ...).
+		runArgs := fs.Args()[2:]
+		if *model != "" {
+			runArgs = append([]string{"-model", *model}, runArgs...)
+		}
+		return runRun(ctx, append(runArgs, entry.textFor(*model)))
+	default:
+		return fmt.Errorf("prompts: unknown action %q (want list, save, delete, or run)", fs.Arg(0))
+	}
+}
+
+// resolveVariants expands -variant values, reading any "@path" value from
+// the named file.
+func resolveVariants(flags tagFlags) (map[string]string, error) {
+	if len(flags) == 0 {
+		return nil, nil
+	}
+	resolved := make(map[string]string, len(flags))
+	for key, val := range flags {
+		if strings.HasPrefix(val, "@") {
+			text, err := readInput(val[1:])
+			if err != nil {
+				return nil, fmt.Errorf("prompts: variant %s: %w", key, err)
+			}
+			val = text
+		}
+		resolved[key] = val
+	}
+	return resolved, nil
+}
+
+// variantKeys lists an entry's variant matchers in stable order.
+func variantKeys(e promptEntry) []string {
+	keys := make([]string, 0, len(e.Variants))
+	for key := range e.Variants {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// snippet truncates s to one line of at most n characters for listings.
+func snippet(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if len(s) > n {
+		s = s[:n-1] + "…"
+	}
+	return s
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+)
+
+// runQuotas prints the account's Bedrock service quotas (requests and
+// tokens per minute per model) next to recent throttle counts from
+// CloudWatch, for sizing batch concurrency before a big run.
+func runQuotas(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("quotas", flag.ExitOnError)
+	region := fs.String("region", "", "AWS region")
+	match := fs.String("match", "claude", "only show quotas whose name contains this (case-insensitive); empty shows all")
+	hours := fs.Int("hours", 24, "how many hours of throttle metrics to aggregate")
+	fs.Parse(args)
+
+	var opts []func(*awsconfig.LoadOptions) error
+	if *region != "" {
+		opts = append(opts, awsconfig.WithRegion(*region))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return err
+	}
+
+	if err := printQuotas(ctx, servicequotas.NewFromConfig(cfg), *match); err != nil {
+		return err
+	}
+	fmt.Println()
+	return printThrottles(ctx, cloudwatch.NewFromConfig(cfg), *hours)
+}
+
+// printQuotas lists the applied Bedrock quotas matching the filter.
+func printQuotas(ctx context.Context, sq *servicequotas.Client, match string) error {
+	match = strings.ToLower(match)
+	type quota struct {
+		name  string
+		value float64
+	}
+	var quotas []quota
+	paginator := servicequotas.NewListServiceQuotasPaginator(sq, &servicequotas.ListServiceQuotasInput{
+		ServiceCode: aws.String("bedrock"),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("quotas: %w", err)
+		}
+		for _, q := range page.Quotas {
+			name := aws.ToString(q.QuotaName)
+			if match != "" && !strings.Contains(strings.ToLower(name), match) {
+				continue
+			}
+			quotas = append(quotas, quota{name: name, value: aws.ToFloat64(q.Value)})
+		}
+	}
+	if len(quotas) == 0 {
+		fmt.Println("no matching Bedrock quotas")
+		return nil
+	}
+	sort.Slice(quotas, func(i, j int) bool { return quotas[i].name < quotas[j].name })
+	fmt.Println("Bedrock service quotas:")
+	for _, q := range quotas {
+		fmt.Printf("  %12.0f  %s\n", q.value, q.name)
+	}
+	return nil
+}
+
+// printThrottles sums per-model invocation throttles over the window.
+func printThrottles(ctx context.Context, cw *cloudwatch.Client, hours int) error {
+	end := time.Now()
+	start := end.Add(-time.Duration(hours) * time.Hour)
+
+	// Discover which models have throttle metrics, then sum each.
+	metrics, err := cw.ListMetrics(ctx, &cloudwatch.ListMetricsInput{
+		Namespace:  aws.String("AWS/Bedrock"),
+		MetricName: aws.String("InvocationThrottles"),
+	})
+	if err != nil {
+		return fmt.Errorf("quotas: listing throttle metrics: %w", err)
+	}
+
+	fmt.Printf("Throttles over the last %dh:\n", hours)
+	found := false
+	for _, metric := range metrics.Metrics {
+		out, err := cw.GetMetricStatistics(ctx, &cloudwatch.GetMetricStatisticsInput{
+			Namespace:  aws.String("AWS/Bedrock"),
+			MetricName: aws.String("InvocationThrottles"),
+			Dimensions: metric.Dimensions,
+			StartTime:  aws.Time(start),
+			EndTime:    aws.Time(end),
+			Period:     aws.Int32(int32(hours) * 3600),
+			Statistics: []cwtypes.Statistic{cwtypes.StatisticSum},
+		})
+		if err != nil {
+			return fmt.Errorf("quotas: throttle statistics: %w", err)
+		}
+		total := 0.0
+		for _, dp := range out.Datapoints {
+			total += aws.ToFloat64(dp.Sum)
+		}
+		if total == 0 {
+			continue
+		}
+		label := "(all models)"
+		for _, d := range metric.Dimensions {
+			if aws.ToString(d.Name) == "ModelId" {
+				label = aws.ToString(d.Value)
+			}
+		}
+		fmt.Printf("  %12.0f  %s\n", total, label)
+		found = true
+	}
+	if !found {
+		fmt.Println("  none recorded")
+	}
+	return nil
+}
@@ -0,0 +1,475 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/postprocess"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/tui"
+)
+
+func runRun(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	var files fileFlags
+	fs.Var(&files, "file", "context document to include with the prompt; repeatable, accepts globs")
+	bestOf := fs.Int("best-of", 1, "sample N candidates concurrently and return the best")
+	consistency := fs.Int("consistency", 0, "sample K answers and return the majority-vote result (for factual/quantitative prompts)")
+	refine := fs.Bool("refine", false, "draft, critique against -criteria, then revise")
+	criteria := fs.String("criteria", "accuracy, clarity, and completeness", "criteria the critique stage checks the draft against")
+	criticModel := fs.String("critic-model", "", "model for the critique stage (default: a cheap Haiku model)")
+	verbose := fs.Bool("verbose", false, "with -best-of, print every candidate and the judge's pick")
+	model := fs.String("model", "", "Bedrock model ID (overrides -route)")
+	route := fs.Bool("route", false, "pick a model automatically based on task complexity")
+	routeLLM := fs.Bool("route-llm", false, "classify complexity with a Haiku call instead of local heuristics")
+	region := fs.String("region", "", "AWS region")
+	maxTokens := fs.Int("max-tokens", 4096, "max output tokens")
+	seed := fs.Int64("seed", -1, "sampling seed for models that support it (-1: unset)")
+	autoContinue := fs.Int("continue", 0, "on max_tokens truncation, auto-continue up to N extra segments")
+	maxWords := fs.Int("max-words", 0, "cap the response at this many words, re-prompting once if exceeded")
+	maxSentences := fs.Int("max-sentences", 0, "cap the response at this many sentences, re-prompting once if exceeded")
+	jsonOut := fs.Bool("json", false, "emit a JSON envelope with reproducibility metadata instead of plain text")
+	post := fs.String("post", "", "post-processing stages applied to the response, comma-separated (e.g. strip-preamble,collapse-whitespace)")
+	answerTag := fs.String("answer-tag", "", "instruct the model to wrap its answer in this tag and print only its contents")
+	afterMarker := fs.String("after-marker", "", "print only the output after this marker string")
+	outPath := fs.String("out", "", "stream the response straight to this file without buffering it in memory")
+	coalesce := fs.Duration("coalesce", 0, "batch streamed deltas for up to this long per write (0 = write per delta)")
+	preflight := fs.Bool("preflight", false, "verify the model exists, is active, and is accessible before invoking")
+	verify := fs.Bool("verify", false, "verification pass: extract the answer's factual claims and check each against the -file sources")
+	video := fs.String("video", "", "video file or s3:// URI to attach, for models that accept video input")
+	tuiMode := fs.Bool("tui", false, "full-screen streaming view with answer, reasoning, and tool panes")
+	thinking := fs.Int("thinking", 0, "extended thinking token budget on models that support it (0: off)")
+	watch := fs.Bool("watch", false, "re-run the prompt whenever a -file input changes")
+	latency := fs.String("latency", "standard", "inference tier: standard, or optimized for latency-optimized models")
+	tier := fs.String("tier", "", "service tier where the model supports one: standard, priority, or flex (default: account default)")
+	longContext := fs.Bool("1m-context", false, "enable the 1M-token context beta on models that support it")
+	guardrail := fs.String("guardrail", "", "guardrail identifier or ARN to apply to the invocation")
+	guardrailVersion := fs.String("guardrail-version", "", "guardrail version (default: the draft)")
+	strictGuardrail := fs.Bool("strict-guardrail", false, "buffer output and suppress it entirely if the guardrail intervenes")
+	managedPrompt := fs.String("managed-prompt", "", "Bedrock Prompt Management prompt (ARN, ID, or name; #variant selects a variant)")
+	promptVersion := fs.String("prompt-version", "", "managed prompt version (default: the draft)")
+	var promptVars tagFlags
+	fs.Var(&promptVars, "prompt-var", "managed prompt variable as key=value, repeatable")
+	var tags tagFlags
+	fs.Var(&tags, "tag", "cost-allocation key=value pair, repeatable; also read from CLAUDE_CLI_TAGS")
+	fs.Parse(args)
+
+	if fs.NArg() == 0 && *managedPrompt == "" {
+		return fmt.Errorf("run: usage: run [options] \"prompt\"")
+	}
+	if *latency != "standard" && *latency != "optimized" {
+		return fmt.Errorf("run: -latency must be standard or optimized, got %q", *latency)
+	}
+	switch *tier {
+	case "", "standard", "priority", "flex":
+		invocationTier = *tier
+	default:
+		return fmt.Errorf("run: -tier must be standard, priority, or flex, got %q", *tier)
+	}
+	metadata := applyTags(tags)
+	pipeline, err := postprocess.Pipeline(*post)
+	if err != nil {
+		return err
+	}
+	if *outPath != "" {
+		// Streaming-only mode never holds the assembled response, so it
+		// cannot combine with anything that needs one.
+		if *jsonOut || *refine || *consistency > 1 || *bestOf > 1 || *autoContinue > 0 ||
+			*maxWords > 0 || *maxSentences > 0 || *strictGuardrail || *verify ||
+			len(pipeline) > 0 || *answerTag != "" || *afterMarker != "" {
+			return fmt.Errorf("run: -out streams without buffering and cannot combine with options that need the full response")
+		}
+	}
+	if *verify && len(files) == 0 {
+		return fmt.Errorf("run: -verify needs -file sources to check claims against")
+	}
+	if *video != "" && (*bestOf > 1 || *consistency > 1 || *refine) {
+		return fmt.Errorf("run: -video cannot combine with -best-of, -consistency, or -refine")
+	}
+	if *tuiMode && (*outPath != "" || *jsonOut || *autoContinue > 0 || *maxWords > 0 || *maxSentences > 0 ||
+		*strictGuardrail || *bestOf > 1 || *consistency > 1 || *refine || *watch) {
+		return fmt.Errorf("run: -tui is a full-screen streaming view and cannot combine with buffered or multi-candidate modes")
+	}
+	emit := func(text string) {
+		if *answerTag != "" {
+			text = postprocess.ExtractTag(text, *answerTag)
+		}
+		if *afterMarker != "" {
+			text = postprocess.AfterMarker(text, *afterMarker)
+		}
+		fmt.Println(postprocess.Run(pipeline, text))
+	}
+	execute := func(ctx context.Context) error {
+		prompt := strings.Join(fs.Args(), " ")
+		if *managedPrompt != "" {
+			mp, err := claude.GetManagedPrompt(ctx, *region, *managedPrompt, *promptVersion)
+			if err != nil {
+				return err
+			}
+			vars := map[string]string(promptVars)
+			if prompt != "" {
+				// Positional text fills the conventional "input" variable
+				// unless one was given explicitly.
+				if vars == nil {
+					vars = map[string]string{}
+				}
+				if _, ok := vars["input"]; !ok {
+					vars["input"] = prompt
+				}
+			}
+			if prompt, err = mp.Render(vars); err != nil {
+				return err
+			}
+			if *model == "" && mp.ModelID != "" {
+				*model = mp.ModelID
+			}
+		}
+		var sourceDocs string
+		if len(files) > 0 {
+			docs, err := readDocuments(files)
+			if err != nil {
+				return err
+			}
+			sourceDocs = docs
+			prompt = docs + "\n" + prompt
+		}
+
+		if *model == "" && (*route || *routeLLM) {
+			*model = routeModel(ctx, *region, prompt, *routeLLM)
+		}
+		client, err := claude.NewClient(ctx, *region, *model)
+		if err != nil {
+			return err
+		}
+		if *preflight {
+			if err := claude.Preflight(ctx, *region, client.ModelID); err != nil {
+				return err
+			}
+		}
+		// verifyClaims runs the optional verification pass, warning about
+		// unsupported claims on stderr and returning the checks for the
+		// envelope. A nil return means verification was off or failed.
+		verifyClaims := func(ctx context.Context, text string) []claude.ClaimCheck {
+			if !*verify {
+				return nil
+			}
+			checks, _, err := client.VerifyClaims(ctx, sourceDocs, text, 0)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "warning: claim verification: %v\n", err)
+				return nil
+			}
+			for _, check := range checks {
+				if !check.Supported {
+					fmt.Fprintf(os.Stderr, "run: unsupported claim: %s (%s)\n", check.Claim, check.Note)
+				}
+			}
+			return checks
+		}
+
+		if *refine {
+			if *criticModel == "" {
+				*criticModel = "anthropic.claude-3-haiku-20240307-v1:0"
+			}
+			critic := client.WithModel(*criticModel)
+			res, err := draftCritiqueRevise(ctx, client, critic, prompt, *criteria, int32(*maxTokens))
+			if err != nil {
+				return err
+			}
+			if *verbose {
+				res.printIntermediate()
+			}
+			emit(res.Final)
+			return nil
+		}
+
+		if *consistency > 1 {
+			answer, confidence, err := selfConsistency(ctx, client, prompt, *consistency, int32(*maxTokens), *verbose)
+			if err != nil {
+				return err
+			}
+			emit(answer)
+			note("confidence: %.0f%% (%d samples)", confidence*100, *consistency)
+			return nil
+		}
+
+		if *bestOf <= 1 {
+			userMsg := claude.UserMessage(prompt)
+			if *video != "" {
+				vid, err := loadVideo(ctx, *region, *video)
+				if err != nil {
+					return err
+				}
+				userMsg = claude.UserMessageWithVideo(prompt, vid)
+			}
+			length := lengthConstraint{Words: *maxWords, Sentences: *maxSentences}
+			req := claude.Request{
+				Messages:         []claude.Message{userMsg},
+				MaxTokens:        int32(*maxTokens),
+				Metadata:         metadata,
+				GuardrailID:      *guardrail,
+				GuardrailVersion: *guardrailVersion,
+				Latency:          *latency,
+				ServiceTier:      *tier,
+			}
+			if *longContext {
+				req.Betas = append(req.Betas, claude.Beta1MContext)
+				note("run: 1M-context enabled; input beyond 200k tokens bills at the long-context rate")
+			}
+			// writeEnvelope carries the stop reason into the envelope so
+			// consumers can tell a complete answer from one a guardrail
+			// cut short.
+			writeEnvelope := func(text string, usage claude.Usage) error {
+				env := claude.NewEnvelope(client.ModelID, req, text, usage)
+				env.StopReason = string(client.LastStopReason())
+				env.GuardrailIntervened = client.GuardrailIntervened()
+				env.Claims = verifyClaims(ctx, text)
+				return json.NewEncoder(os.Stdout).Encode(env)
+			}
+			if length.active() {
+				req.System = length.instruction()
+			}
+			if *answerTag != "" {
+				instruction := fmt.Sprintf("Wrap your final answer in <%s></%s> tags.", *answerTag, *answerTag)
+				if req.System != "" {
+					req.System += " "
+				}
+				req.System += instruction
+			}
+			if *seed >= 0 {
+				req.Seed = seed
+			}
+			if *thinking > 0 {
+				req.ThinkingBudget = int32(*thinking)
+			}
+			if *tuiMode {
+				ui := &tui.UI{Client: client, CostTier: *tier}
+				start := time.Now()
+				text, usage, err := ui.Run(ctx, req)
+				if err != nil {
+					return err
+				}
+				recordInvocation(ctx, "run", client.ModelID, prompt, text, client.LastRequestID(), usage, time.Since(start))
+				emit(text)
+				verifyClaims(ctx, text)
+				return nil
+			}
+			if *outPath != "" {
+				f, err := os.Create(*outPath)
+				if err != nil {
+					return err
+				}
+				start := time.Now()
+				sp := startSpinner("run: streaming to " + *outPath)
+				stats, err := client.ConverseStreamTo(ctx, req, firstTokenWriter{w: f, sp: sp})
+				sp.Stop()
+				if closeErr := f.Close(); err == nil {
+					err = closeErr
+				}
+				if err != nil {
+					return err
+				}
+				// The response was never assembled, so the audit record
+				// carries its accounting rather than its content.
+				recordInvocation(ctx, "run", client.ModelID, prompt, "", client.LastRequestID(), stats.Usage, time.Since(start))
+				note("run: wrote %d bytes to %s (sha256 %s)", stats.Bytes, *outPath, stats.SHA256)
+				if client.GuardrailIntervened() {
+					fmt.Fprintln(os.Stderr, "run: guardrail intervened; the output file may be partial")
+				}
+				return nil
+			}
+			if length.active() {
+				// Buffer the answer so an over-length response can be replaced
+				// by the re-prompted version before printing.
+				start := time.Now()
+				text, usage, err := client.ConverseUsage(ctx, req)
+				if err != nil {
+					return err
+				}
+				if text, err = length.enforce(ctx, client, req, text, &usage); err != nil {
+					return err
+				}
+				recordInvocation(ctx, "run", client.ModelID, prompt, text, client.LastRequestID(), usage, time.Since(start))
+				if *jsonOut {
+					return writeEnvelope(text, usage)
+				}
+				emit(text)
+				return nil
+			}
+			start := time.Now()
+			if *autoContinue > 0 {
+				text, usage, err := client.ConverseUntilEnd(ctx, req, 1+*autoContinue)
+				if err != nil {
+					return err
+				}
+				recordInvocation(ctx, "run", client.ModelID, prompt, text, client.LastRequestID(), usage, time.Since(start))
+				if *jsonOut {
+					return writeEnvelope(text, usage)
+				}
+				emit(text)
+				return nil
+			}
+			if *jsonOut {
+				text, usage, err := client.ConverseUsage(ctx, req)
+				if err != nil {
+					return err
+				}
+				recordInvocation(ctx, "run", client.ModelID, prompt, text, client.LastRequestID(), usage, time.Since(start))
+				return writeEnvelope(text, usage)
+			}
+			if len(pipeline) > 0 || *answerTag != "" || *afterMarker != "" || *strictGuardrail {
+				// Post-processing needs the assembled response; buffer instead
+				// of streaming. Strict guardrail mode buffers too, so no
+				// partial content escapes before an intervention is known.
+				sp := startSpinner("run: waiting for " + client.ModelID)
+				text, usage, err := client.ConverseUsage(ctx, req)
+				sp.Stop()
+				if err != nil {
+					return err
+				}
+				recordInvocation(ctx, "run", client.ModelID, prompt, text, client.LastRequestID(), usage, time.Since(start))
+				if client.GuardrailIntervened() {
+					fmt.Fprintln(os.Stderr, "run: guardrail intervened")
+				}
+				emit(text)
+				verifyClaims(ctx, text)
+				return nil
+			}
+			// The spinner covers the silent wait before the first token; it
+			// clears itself as soon as text starts arriving.
+			sp := startSpinner("run: waiting for " + client.ModelID)
+			onDelta := func(delta string) { sp.Stop(); fmt.Print(delta) }
+			var co *claude.Coalescer
+			if *coalesce > 0 {
+				// Batch tiny deltas into fewer, larger writes — noticeably
+				// smoother on terminals reached over SSH.
+				co = claude.NewCoalescer(onDelta, 0, *coalesce)
+				onDelta = co.Add
+			}
+			text, usage, err := client.ConverseStreamUsage(ctx, req, onDelta)
+			if co != nil {
+				co.Close()
+			}
+			sp.Stop()
+			fmt.Println()
+			if err == nil {
+				recordInvocation(ctx, "run", client.ModelID, prompt, text, client.LastRequestID(), usage, time.Since(start))
+				if client.GuardrailIntervened() {
+					// The stream was already printed; mark it as cut short
+					// rather than silently passing it off as complete.
+					fmt.Fprintln(os.Stderr, "run: guardrail intervened; the output above may be partial")
+				}
+				verifyClaims(ctx, text)
+			}
+			return err
+		}
+
+		answer, err := bestOfN(ctx, client, prompt, *bestOf, int32(*maxTokens), *verbose)
+		if err != nil {
+			return err
+		}
+		emit(answer)
+		return nil
+	}
+
+	if *watch {
+		if len(files) == 0 {
+			return fmt.Errorf("run: -watch requires at least one -file")
+		}
+		paths, err := expandFiles(files)
+		if err != nil {
+			return err
+		}
+		return watchFiles(ctx, paths, execute)
+	}
+	return execute(ctx)
+}
+
+// bestOfN fires n generations concurrently at spread-out temperatures, then
+// asks the model to judge which candidate answers the prompt best.
+func bestOfN(ctx context.Context, client *claude.Client, prompt string, n int, maxTokens int32, verbose bool) (string, error) {
+	candidates := make([]string, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Spread temperatures across [0.2, 1.0] so candidates differ.
+			temp := float32(0.2)
+			if n > 1 {
+				temp += float32(i) * 0.8 / float32(n-1)
+			}
+			candidates[i], errs[i] = client.Converse(ctx, claude.Request{
+				Messages:    []claude.Message{claude.UserMessage(prompt)},
+				MaxTokens:   maxTokens,
+				Temperature: aws.Float32(temp),
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	var ok []string
+	for i, err := range errs {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: candidate %d failed: %v\n", i+1, err)
+			continue
+		}
+		ok = append(ok, candidates[i])
+	}
+	if len(ok) == 0 {
+		return "", fmt.Errorf("best-of: all %d candidates failed: %w", n, errs[0])
+	}
+	if len(ok) == 1 {
+		return ok[0], nil
+	}
+
+	best, err := judgeCandidates(ctx, client, prompt, ok)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: judging failed (%v); returning first candidate\n", err)
+		best = 0
+	}
+	if verbose {
+		for i, c := range ok {
+			marker := " "
+			if i == best {
+				marker = "*"
+			}
+			fmt.Fprintf(os.Stderr, "--- candidate %d %s---\n%s\n", i+1, marker, c)
+		}
+	}
+	return ok[best], nil
+}
+
+// judgeCandidates asks the model which candidate best answers the prompt and
+// returns its zero-based index.
+func judgeCandidates(ctx context.Context, client *claude.Client, prompt string, candidates []string) (int, error) {
+	var sb strings.Builder
+	sb.WriteString("<task>\n" + prompt + "\n</task>\n\n")
+	for i, c := range candidates {
+		fmt.Fprintf(&sb, "<response id=\"%d\">\n%s\n</response>\n", i+1, c)
+	}
+	sb.WriteString(fmt.Sprintf("\nWhich response answers the task best? Reply with only its id (1-%d).", len(candidates)))
+
+	verdict, err := client.Converse(ctx, claude.Request{
+		System:    "You are judging candidate responses for accuracy, completeness, and clarity.",
+		Messages:  []claude.Message{claude.UserMessage(sb.String())},
+		MaxTokens: 16,
+	})
+	if err != nil {
+		return 0, err
+	}
+	id, err := strconv.Atoi(strings.TrimSpace(verdict))
+	if err != nil || id < 1 || id > len(candidates) {
+		return 0, fmt.Errorf("unparseable verdict %q", verdict)
+	}
+	return id - 1, nil
+}
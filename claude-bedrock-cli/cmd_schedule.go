@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/events"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/schedule"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/sink"
+)
+
+// runSchedule runs the recurring jobs in a YAML schedule definition until
+// interrupted, or one named job immediately with -now.
+func runSchedule(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("schedule", flag.ExitOnError)
+	def := fs.String("f", "", "schedule definition YAML file")
+	model := fs.String("model", "", "default Bedrock model ID for jobs without one")
+	region := fs.String("region", "", "AWS region")
+	now := fs.String("now", "", "run this job once immediately and exit")
+	emitEvents := fs.Bool("events", false, "emit job lifecycle events to EventBridge")
+	eventBus := fs.String("event-bus", "", "EventBridge bus for -events (default: the account default bus)")
+	fs.Parse(args)
+
+	if *def == "" {
+		return fmt.Errorf("schedule: usage: schedule -f <jobs.yaml> [-now job]")
+	}
+	cfg, err := schedule.LoadConfig(*def)
+	if err != nil {
+		return err
+	}
+	client, err := claude.NewClient(ctx, *region, *model)
+	if err != nil {
+		return err
+	}
+	// Sinks are built once per spec so repeated runs of a job reuse the
+	// same AWS clients.
+	sinks := map[string]sink.Sink{}
+	sinkFor := func(job schedule.Job) (sink.Sink, error) {
+		if s, ok := sinks[job.Sink]; ok {
+			return s, nil
+		}
+		s, err := sink.New(ctx, *region, job.Sink)
+		if err != nil {
+			return nil, err
+		}
+		sinks[job.Sink] = s
+		return s, nil
+	}
+	// Validate every sink spec up front rather than at first delivery.
+	for _, job := range cfg.Jobs {
+		if _, err := sinkFor(job); err != nil {
+			return fmt.Errorf("job %s: %w", job.Name, err)
+		}
+	}
+
+	subjectFor := func(job schedule.Job, status string) string {
+		tmpl := job.Subject
+		if tmpl == "" {
+			tmpl = "claude-bedrock-cli: job {{job}} {{status}}"
+		}
+		return sink.Render(tmpl, map[string]string{
+			"job":    job.Name,
+			"status": status,
+			"time":   time.Now().Format(time.RFC3339),
+		})
+	}
+	runner := &schedule.Runner{
+		Client: client,
+		Jobs:   cfg.Jobs,
+		Deliver: func(ctx context.Context, job schedule.Job, output string) error {
+			s, err := sinkFor(job)
+			if err != nil {
+				return err
+			}
+			return s.Deliver(ctx, subjectFor(job, "succeeded"), output)
+		},
+		OnError: func(job schedule.Job, err error) {
+			log.Printf("schedule: job %s: %v", job.Name, err)
+			s, sinkErr := sinkFor(job)
+			if sinkErr != nil {
+				return
+			}
+			if sinkErr := s.Deliver(ctx, subjectFor(job, "failed"), err.Error()); sinkErr != nil {
+				log.Printf("schedule: job %s: delivering failure notice: %v", job.Name, sinkErr)
+			}
+		},
+	}
+	if *emitEvents {
+		emitter, err := events.NewEmitter(ctx, *region, *eventBus)
+		if err != nil {
+			return err
+		}
+		runner.Notify = func(ctx context.Context, job schedule.Job, status string, usage claude.Usage, duration time.Duration, jobErr error) {
+			modelID := job.Model
+			if modelID == "" {
+				modelID = client.ModelID
+			}
+			cost, _ := claude.EstimateCost(modelID, usage)
+			detail := events.JobDetail{
+				Job:          job.Name,
+				Status:       status,
+				Model:        modelID,
+				InputTokens:  usage.InputTokens,
+				OutputTokens: usage.OutputTokens,
+				CostUSD:      cost,
+				DurationMS:   duration.Milliseconds(),
+			}
+			if jobErr != nil {
+				detail.Error = jobErr.Error()
+			}
+			if err := emitter.EmitJob(ctx, detail); err != nil {
+				log.Printf("schedule: job %s: emitting event: %v", job.Name, err)
+			}
+		}
+	}
+
+	if *now != "" {
+		return runner.RunJobNow(ctx, *now)
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	log.Printf("schedule: running %d jobs from %s", len(cfg.Jobs), *def)
+	if err := runner.Start(ctx); err != nil && err != context.Canceled {
+		return err
+	}
+	return nil
+}
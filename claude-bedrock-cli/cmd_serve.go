@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/bedrock"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/grpcserver"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/secrets"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/server"
+)
+
+func runServe(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", ":8080", "listen address")
+	grpcAddr := fs.String("grpc-addr", "", "also serve gRPC chat streaming on this address (see grpcserver/proto/chat.proto)")
+	model := fs.String("model", "", "default Bedrock model ID")
+	region := fs.String("region", "", "AWS region")
+	maxTokens := fs.Int("max-tokens", 4096, "ceiling on output tokens per request")
+	tenants := fs.String("tenants", "", "JSON file defining tenants, API keys, quotas, and model allow-lists")
+	authMode := fs.String("auth", "api-key", "authentication mode: api-key, iam (presigned GetCallerIdentity), or cognito")
+	cognitoPool := fs.String("cognito-pool", "", "Cognito user pool ID (required for -auth cognito)")
+	maxBody := fs.Int("max-body-mb", 10, "maximum request body size in MiB")
+	slowClient := fs.Duration("slow-client-timeout", 30*time.Second, "cancel a stream when the client stalls this long")
+	coalesce := fs.Duration("coalesce", 0, "batch streamed deltas for up to this long per SSE frame (0 = frame per delta)")
+	drain := fs.Duration("drain", server.DefaultDrainTimeout, "how long to let in-flight requests finish on SIGTERM")
+	maxIdle := fs.Int("max-idle-conns", 0, "idle connections kept open to Bedrock (0 = SDK default)")
+	proxy := fs.String("proxy", "", "HTTPS proxy URL for Bedrock traffic (overrides HTTPS_PROXY)")
+	caBundle := fs.String("ca-bundle", "", "PEM file of extra trusted CAs (TLS-intercepting proxies)")
+	endpointURL := fs.String("endpoint-url", "", "Bedrock Runtime endpoint override, e.g. a PrivateLink VPC endpoint URL")
+	fips := fs.Bool("fips", false, "use FIPS endpoints (required in some GovCloud deployments)")
+	fs.Parse(args)
+
+	client, err := claude.NewClientHTTP(ctx, *region, *model, claude.HTTPOptions{
+		MaxIdleConnsPerHost: *maxIdle,
+		ProxyURL:            *proxy,
+		CABundle:            *caBundle,
+		EndpointURL:         *endpointURL,
+		UseFIPS:             *fips,
+	})
+	if err != nil {
+		return err
+	}
+
+	var auth server.Auth
+	if *tenants != "" {
+		var resolver *secrets.Resolver
+		buildAuth := func() (server.Auth, error) {
+			keys, err := server.LoadTenants(*tenants)
+			if err != nil {
+				return nil, err
+			}
+			hasRefs := false
+			for _, t := range keys.Tenants() {
+				if secrets.IsRef(t.APIKey) {
+					hasRefs = true
+					break
+				}
+			}
+			if hasRefs {
+				if resolver == nil {
+					resolver, err = secrets.NewResolver(ctx, *region)
+					if err != nil {
+						return nil, err
+					}
+				}
+				if err := keys.ResolveSecrets(ctx, resolver.Resolve); err != nil {
+					return nil, err
+				}
+			}
+			switch *authMode {
+			case "api-key":
+				return keys, nil
+			case "iam":
+				return &server.IAMAuth{Tenants: keys.Tenants()}, nil
+			case "cognito":
+				if *cognitoPool == "" {
+					return nil, fmt.Errorf("serve: -auth cognito requires -cognito-pool")
+				}
+				return &server.CognitoAuth{
+					Region:     *region,
+					UserPoolID: *cognitoPool,
+					Tenants:    keys.Tenants(),
+				}, nil
+			default:
+				return nil, fmt.Errorf("serve: unknown -auth mode %q", *authMode)
+			}
+		}
+		initial, err := buildAuth()
+		if err != nil {
+			return err
+		}
+		reloadable := server.NewReloadableAuth(initial)
+		stop, err := server.WatchFile(*tenants, func() error {
+			next, err := buildAuth()
+			if err != nil {
+				return err
+			}
+			reloadable.Set(next)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		defer stop()
+		auth = reloadable
+	} else {
+		log.Print("warning: no -tenants file given; serving without authentication")
+	}
+
+	srv := server.New(client, auth, int32(*maxTokens))
+	srv.MaxBodyBytes = int64(*maxBody) << 20
+	srv.SlowClientTimeout = *slowClient
+	srv.CoalesceWindow = *coalesce
+	srv.DrainTimeout = *drain
+	srv.Region = *region
+	srv.ReadyCheck = bedrockReadyCheck(ctx, *region)
+	// Whichever listener terminates first decides the exit; a gRPC bind
+	// failure surfaces as a returned error instead of killing the process
+	// mid-drain.
+	errc := make(chan error, 2)
+	if *grpcAddr != "" {
+		// The gRPC endpoint has no authentication, so alongside a tenants
+		// file it would silently bypass every per-tenant control the HTTP
+		// proxy enforces.
+		if *tenants != "" {
+			return fmt.Errorf("serve: -grpc-addr serves without tenant auth and cannot be combined with -tenants")
+		}
+		go func() {
+			errc <- grpcserver.New(client, int32(*maxTokens)).ListenAndServe(*grpcAddr)
+		}()
+	}
+	go func() {
+		errc <- srv.ListenAndServe(*addr)
+	}()
+	if err := <-errc; err != nil {
+		return fmt.Errorf("serve: %w", err)
+	}
+	return nil
+}
+
+// bedrockReadyCheck returns a /readyz probe that lists foundation models on
+// the Bedrock control plane — a free call that fails when the region is
+// unreachable or credentials are invalid or expired.
+func bedrockReadyCheck(ctx context.Context, region string) func(context.Context) error {
+	var opts []func(*awsconfig.LoadOptions) error
+	if region != "" {
+		opts = append(opts, awsconfig.WithRegion(region))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return func(context.Context) error { return err }
+	}
+	control := bedrock.NewFromConfig(cfg)
+	return func(ctx context.Context) error {
+		_, err := control.ListFoundationModels(ctx, &bedrock.ListFoundationModelsInput{})
+		return err
+	}
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/embed"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/session"
+)
+
+// runSessions maintains the persisted conversation store: listing sessions,
+// scrubbing individual transcripts, and enforcing retention policies.
+func runSessions(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("sessions", flag.ExitOnError)
+	sessionDir := fs.String("session-dir", "", "session store directory (default: ~/.claude-bedrock-cli/sessions)")
+	maxAge := fs.Duration("max-age", 0, "purge sessions not updated within this window (e.g. 720h)")
+	maxSessions := fs.Int("max", 0, "purge all but the newest N sessions")
+	format := fs.String("format", "portable", "transcript format for export/import: portable, anthropic, or openai")
+	out := fs.String("out", "", "with export, write to this file instead of stdout")
+	semantic := fs.Bool("semantic", false, "with search, rank turns by embedding similarity instead of substring match")
+	embedModel := fs.String("embed-model", "", "Bedrock embeddings model ID for -semantic")
+	region := fs.String("region", "", "AWS region")
+	top := fs.Int("top", 10, "with -semantic, how many best-matching turns to show")
+	fs.Parse(args)
+
+	store, err := session.OpenStore(*sessionDir)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case fs.NArg() == 0 || fs.Arg(0) == "list":
+		return listSessions(ctx, store)
+	case fs.Arg(0) == "delete":
+		if fs.NArg() != 2 {
+			return fmt.Errorf("sessions: usage: sessions delete <session-id>")
+		}
+		return store.Delete(fs.Arg(1))
+	case fs.Arg(0) == "purge":
+		if *maxAge <= 0 && *maxSessions <= 0 {
+			return fmt.Errorf("sessions: purge needs -max-age and/or -max")
+		}
+		removed, err := store.Purge(session.Retention{
+			MaxAge:      *maxAge,
+			MaxSessions: *maxSessions,
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("purged %d session(s)\n", len(removed))
+		for _, id := range removed {
+			fmt.Println(id)
+		}
+		return nil
+	case fs.Arg(0) == "search":
+		if fs.NArg() < 2 {
+			return fmt.Errorf("sessions: usage: sessions search [-semantic] <query>")
+		}
+		query := strings.Join(fs.Args()[1:], " ")
+		if *semantic {
+			return searchSemantic(ctx, store, query, *region, *embedModel, *top)
+		}
+		matches, err := store.Search(ctx, query)
+		if err != nil {
+			return err
+		}
+		return printMatches(matches)
+	case fs.Arg(0) == "export":
+		if fs.NArg() != 2 {
+			return fmt.Errorf("sessions: usage: sessions export [-format portable|anthropic|openai] [-out file] <session-id>")
+		}
+		return exportSession(ctx, store, fs.Arg(1), *format, *out)
+	case fs.Arg(0) == "import":
+		if fs.NArg() != 2 {
+			return fmt.Errorf("sessions: usage: sessions import [-format portable|anthropic|openai] <file>")
+		}
+		return importSession(ctx, store, fs.Arg(1), *format)
+	default:
+		return fmt.Errorf("sessions: unknown action %q (want list, delete, purge, search, export, or import)", fs.Arg(0))
+	}
+}
+
+// exportSession renders a stored session's active branch in the requested
+// transcript format.
+func exportSession(ctx context.Context, store *session.Store, id, format, out string) error {
+	s, err := store.Load(ctx, id)
+	if err != nil {
+		return err
+	}
+	p := session.ExportPortable(s)
+	var data []byte
+	switch format {
+	case "portable":
+		data, err = json.MarshalIndent(p, "", "  ")
+	case "anthropic":
+		data, err = p.MarshalAnthropic()
+	case "openai":
+		data, err = p.MarshalOpenAI()
+	default:
+		return fmt.Errorf("sessions: unknown format %q (want portable, anthropic, or openai)", format)
+	}
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if out == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(out, data, 0o644); err != nil {
+		return err
+	}
+	note("sessions: exported %s to %s", id, out)
+	return nil
+}
+
+// importSession stores a transcript file as a new session and prints its ID,
+// ready for chat -resume.
+func importSession(ctx context.Context, store *session.Store, path, format string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var p *session.Portable
+	switch format {
+	case "portable":
+		p = &session.Portable{}
+		if err := json.Unmarshal(data, p); err != nil {
+			return fmt.Errorf("portable transcript: %w", err)
+		}
+	case "anthropic":
+		p, err = session.ParseAnthropic(data)
+	case "openai":
+		p, err = session.ParseOpenAI(data)
+	default:
+		return fmt.Errorf("sessions: unknown format %q (want portable, anthropic, or openai)", format)
+	}
+	if err != nil {
+		return err
+	}
+	s, err := session.ImportPortable(p)
+	if err != nil {
+		return err
+	}
+	if p.System != "" {
+		note("sessions: transcript has a system prompt; pass it to chat -system when resuming")
+	}
+	if err := store.Save(ctx, s); err != nil {
+		return err
+	}
+	fmt.Println(s.ID)
+	return nil
+}
+
+// listSessions prints one row per stored session with the generated title
+// and tags when chat has filled them in. Sessions that cannot be loaded (for
+// example encrypted ones when no key is passed) still list by ID.
+func listSessions(ctx context.Context, store *session.Store) error {
+	ids, err := store.List()
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "SESSION\tTURNS\tTITLE\tTAGS")
+	for _, id := range ids {
+		s, err := store.Load(ctx, id)
+		if err != nil {
+			fmt.Fprintf(tw, "%s\t\t\t\n", id)
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\n", id, len(s.Turns()),
+			snippet(s.Title, 50), strings.Join(s.Tags, ","))
+	}
+	return tw.Flush()
+}
+
+// printMatches renders search hits one turn per row, newest session first.
+func printMatches(matches []session.TurnMatch) error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "SESSION\tBRANCH\tTURN\tROLE\tWHEN\tTEXT")
+	for _, m := range matches {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%s\n", m.SessionID, m.Branch, m.Index,
+			m.Turn.Role, m.Turn.Time.Format("2006-01-02 15:04"), snippet(m.Turn.Text, 60))
+	}
+	return tw.Flush()
+}
+
+// searchSemantic ranks every stored turn by embedding similarity to the
+// query, finding answers phrased nothing like the search terms. Each turn
+// costs one embedding call, so large stores take a moment.
+func searchSemantic(ctx context.Context, store *session.Store, query, region, embedModel string, top int) error {
+	// The empty query matches every turn; semantic ranking wants them all.
+	turns, err := store.Search(ctx, "")
+	if err != nil {
+		return err
+	}
+	if len(turns) == 0 {
+		return nil
+	}
+	embedder, err := embed.NewClient(ctx, region, embedModel)
+	if err != nil {
+		return err
+	}
+	qv, err := embedder.Embed(ctx, query)
+	if err != nil {
+		return err
+	}
+	note("sessions: embedding %d turns", len(turns))
+	type scored struct {
+		match session.TurnMatch
+		score float64
+	}
+	ranked := make([]scored, 0, len(turns))
+	for _, m := range turns {
+		if strings.TrimSpace(m.Turn.Text) == "" {
+			continue
+		}
+		tv, err := embedder.Embed(ctx, m.Turn.Text)
+		if err != nil {
+			return err
+		}
+		ranked = append(ranked, scored{match: m, score: embed.Cosine(qv, tv)})
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].score > ranked[j].score })
+	if len(ranked) > top {
+		ranked = ranked[:top]
+	}
+	matches := make([]session.TurnMatch, len(ranked))
+	for i, r := range ranked {
+		matches[i] = r.match
+	}
+	return printMatches(matches)
+}
+
+// applyRetentionEnv enforces the CLAUDE_CLI_SESSION_MAX_AGE retention
+// variable against the store; chat calls it on startup so stale transcripts
+// are dropped without a separate cron job.
+func applyRetentionEnv(store *session.Store) {
+	raw := os.Getenv("CLAUDE_CLI_SESSION_MAX_AGE")
+	if raw == "" {
+		return
+	}
+	maxAge, err := time.ParseDuration(raw)
+	if err != nil || maxAge <= 0 {
+		log.Printf("ignoring CLAUDE_CLI_SESSION_MAX_AGE=%q: not a positive duration", raw)
+		return
+	}
+	if _, err := store.Purge(session.Retention{MaxAge: maxAge}); err != nil {
+		log.Printf("session retention purge: %v", err)
+	}
+}
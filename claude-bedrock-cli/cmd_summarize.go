@@ -0,0 +1,246 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/chunk"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/embed"
+)
+
+// summaryStyle is a curated system prompt plus output constraints for a
+// consistent summary format.
+type summaryStyle struct {
+	system      string
+	wordTarget  int // 0 means no target
+	description string
+}
+
+var summaryStyles = map[string]summaryStyle{
+	"bullet": {
+		system:      "You are an expert summarizer. Summarize the document as a flat list of concise bullet points, one key fact or decision per bullet. Use \"- \" bullets and no other formatting. Do not add an introduction or conclusion.",
+		description: "flat bullet-point list",
+	},
+	"executive": {
+		system:      "You are an expert analyst writing for senior executives. Produce an executive summary: one short paragraph of context, then the 3-5 most important findings and their business implications, then recommended next steps. Be direct and quantitative where the document allows.",
+		wordTarget:  250,
+		description: "executive summary with findings and next steps",
+	},
+	"eli5": {
+		system:      "Summarize the document so that a bright twelve-year-old could understand it. Use plain words, short sentences, and everyday analogies. Avoid jargon entirely; if a technical term is unavoidable, explain it in one sentence.",
+		description: "plain-language explanation",
+	},
+	"brief": {
+		system:      "Summarize the document in a single tight paragraph covering only what matters most.",
+		wordTarget:  100,
+		description: "single paragraph, ~100 words",
+	},
+}
+
+func summaryStyleNames() []string {
+	names := make([]string, 0, len(summaryStyles))
+	for name := range summaryStyles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func runSummarize(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("summarize", flag.ExitOnError)
+	file := fs.String("file", "-", "input file or archive (.zip, .tar, .tar.gz; - for stdin)")
+	include := fs.String("include", "", "comma-separated globs selecting archive entries to summarize")
+	exclude := fs.String("exclude", "", "comma-separated globs of archive entries to skip")
+	style := fs.String("style", "bullet", "summary style: "+strings.Join(summaryStyleNames(), ", "))
+	maxWords := fs.Int("max-words", 0, "word target for the summary (overrides the style's default)")
+	hierarchical := fs.Bool("hierarchical", false, "multi-level summarization for book-length inputs (automatic above 100k chars)")
+	outline := fs.Bool("outline", false, "with -hierarchical, print the full tree of intermediate summaries as an outline")
+	incremental := fs.Bool("incremental", false, "cache chunk summaries by content hash and reuse them on re-runs")
+	cacheDir := fs.String("cache-dir", "", "chunk-summary cache directory (default: user cache dir)")
+	clearCache := fs.Bool("clear-cache", false, "drop all cached chunk summaries and exit")
+	model := fs.String("model", "", "Bedrock model ID")
+	region := fs.String("region", "", "AWS region")
+	maxTokens := fs.Int("max-tokens", 4096, "max output tokens")
+	outputLang := fs.String("output-lang", "", "require the summary in this language (ISO code, e.g. de)")
+	longContext := fs.Bool("1m-context", false, "enable the 1M-token context beta and summarize much larger inputs in one shot")
+	groundedness := fs.Bool("groundedness", false, "score each summary paragraph by n-gram overlap with the source and warn on unsupported sections")
+	chunker := fs.String("chunker", "paragraph", "chunking strategy for large inputs: paragraph, sentence, token, markdown, or semantic")
+	embedModel := fs.String("embed-model", "", "Bedrock embeddings model ID (used by -chunker semantic)")
+	fs.Parse(args)
+
+	var cache *summaryCache
+	if *incremental || *clearCache {
+		var err error
+		cache, err = openSummaryCache(*cacheDir)
+		if err != nil {
+			return fmt.Errorf("summarize: opening cache: %w", err)
+		}
+	}
+	if *clearCache {
+		return cache.clear()
+	}
+
+	preset, ok := summaryStyles[*style]
+	if !ok {
+		return fmt.Errorf("summarize: unknown style %q (available: %s)", *style, strings.Join(summaryStyleNames(), ", "))
+	}
+	target := preset.wordTarget
+	if *maxWords > 0 {
+		target = *maxWords
+	}
+
+	var text string
+	if isArchive(*file) {
+		entries, err := readArchive(*file, splitPatterns(*include), splitPatterns(*exclude))
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return fmt.Errorf("summarize: no archive entries matched the include/exclude patterns")
+		}
+		var sb strings.Builder
+		for _, entry := range entries {
+			fmt.Fprintf(&sb, "<document name=%q>\n%s\n</document>\n", entry.Name, entry.Text)
+		}
+		text = sb.String()
+	} else {
+		var err error
+		text, err = readInput(*file)
+		if err != nil {
+			return err
+		}
+	}
+	client, err := claude.NewClient(ctx, *region, *model)
+	if err != nil {
+		return err
+	}
+
+	// reportGroundedness flags summary paragraphs sharing almost no phrasing
+	// with the input; see groundedness.go.
+	reportGroundedness := func(summary string) {
+		if !*groundedness {
+			return
+		}
+		for i, ps := range groundednessScores(text, summary) {
+			note("summarize: paragraph %d groundedness %.2f", i+1, ps.Score)
+			if ps.Score < groundednessWarnBelow {
+				snippet := ps.Paragraph
+				if len(snippet) > 80 {
+					snippet = snippet[:80] + "..."
+				}
+				fmt.Fprintf(os.Stderr, "summarize: paragraph %d may be unsupported by the input (groundedness %.2f): %s\n", i+1, ps.Score, snippet)
+			}
+		}
+	}
+
+	system := preset.system
+	if target > 0 {
+		system += fmt.Sprintf(" Keep the summary under %d words.", target)
+	}
+	if *outputLang != "" {
+		name, ok := languageNames[*outputLang]
+		if !ok {
+			return fmt.Errorf("summarize: unsupported -output-lang %q", *outputLang)
+		}
+		system += fmt.Sprintf(" Write the summary in %s, regardless of the document's language.", name)
+	}
+
+	// Book-length inputs cannot be summarized in one shot without truncation;
+	// build a summary tree instead. Incremental runs always chunk so that
+	// cached chunk summaries can be reused. The 1M-context beta raises the
+	// one-shot ceiling to roughly the extended window.
+	oneShotLimit := 100000
+	var betas []string
+	if *longContext {
+		oneShotLimit = 3000000
+		betas = []string{claude.Beta1MContext}
+		note("summarize: 1M-context enabled; input beyond 200k tokens bills at the long-context rate")
+	}
+	if *hierarchical || *incremental || len(text) > oneShotLimit {
+		var splitter chunk.Splitter
+		if *chunker == "semantic" {
+			embedder, err := embed.NewClient(ctx, *region, *embedModel)
+			if err != nil {
+				return err
+			}
+			splitter = chunk.NewSemantic(embedder, hierChunkChars)
+		} else if splitter, err = chunk.NewSplitter(*chunker, hierChunkChars); err != nil {
+			return err
+		}
+		root, err := summarizeHierarchical(ctx, client, splitter, text, system, int32(*maxTokens), cache)
+		if err != nil {
+			return err
+		}
+		if *outline {
+			var sb strings.Builder
+			writeOutline(&sb, root, 0)
+			fmt.Print(sb.String())
+		} else {
+			fmt.Println(root.Summary)
+		}
+		reportGroundedness(root.Summary)
+		return nil
+	}
+
+	summary, err := client.Converse(ctx, claude.Request{
+		System:    system,
+		Messages:  []claude.Message{claude.UserMessage(text)},
+		MaxTokens: int32(*maxTokens),
+		Betas:     betas,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Enforce the word target by re-prompting once when exceeded; models
+	// routinely overshoot soft instructions.
+	if target > 0 && wordCount(summary) > target {
+		summary, err = client.Converse(ctx, claude.Request{
+			System: system,
+			Messages: []claude.Message{
+				claude.UserMessage(text),
+				claude.AssistantMessage(summary),
+				claude.UserMessage(fmt.Sprintf("That summary is %d words, over the %d-word limit. Rewrite it under %d words, keeping the most important content.", wordCount(summary), target, target)),
+			},
+			MaxTokens: int32(*maxTokens),
+			Betas:     betas,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Verify the output language locally and re-prompt once on mismatch;
+	// models sometimes fall back to the document's language.
+	if *outputLang != "" {
+		if got := detectLanguage(summary); got != "" && got != *outputLang {
+			summary, err = client.Converse(ctx, claude.Request{
+				System: system,
+				Messages: []claude.Message{
+					claude.UserMessage(text),
+					claude.AssistantMessage(summary),
+					claude.UserMessage(fmt.Sprintf("That summary is not in %s. Rewrite it entirely in %s.",
+						languageNames[*outputLang], languageNames[*outputLang])),
+				},
+				MaxTokens: int32(*maxTokens),
+				Betas:     betas,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	fmt.Println(summary)
+	reportGroundedness(summary)
+	return nil
+}
+
+func wordCount(s string) int {
+	return len(strings.Fields(s))
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/chunk"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+)
+
+// summaryNode is one node in the tree of intermediate summaries produced by
+// hierarchical summarization. Leaves summarize source chunks; interior nodes
+// summarize their children's summaries.
+type summaryNode struct {
+	Title    string
+	Summary  string
+	Children []*summaryNode
+}
+
+const (
+	// hierChunkChars is the size of a leaf source chunk.
+	hierChunkChars = 24000
+	// hierFanIn is how many child summaries are merged per interior node.
+	hierFanIn = 8
+)
+
+// summarizeHierarchical builds a multi-level summary: source chunks are
+// summarized individually, the chunk summaries are merged in groups, and the
+// merging repeats until a single root summary covers the whole document.
+// When cache is non-nil, chunk summaries are reused by content hash so only
+// chunks that changed since the last run are re-summarized. splitter picks
+// the chunking strategy; nil falls back to paragraph splitting.
+func summarizeHierarchical(ctx context.Context, client *claude.Client, splitter chunk.Splitter, text, system string, maxTokens int32, cache *summaryCache) (*summaryNode, error) {
+	if splitter == nil {
+		splitter = chunk.Paragraphs{MaxChars: hierChunkChars}
+	}
+	pieces, err := splitter.Split(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+	level := make([]*summaryNode, 0, len(pieces))
+	bar := newProgressBar(len(pieces))
+	defer bar.Close()
+	for i, piece := range pieces {
+		bar.Start(fmt.Sprintf("section %d", i+1))
+		summary, cached := "", false
+		if cache != nil {
+			summary, cached = cache.get(system, piece)
+		}
+		if !cached {
+			var err error
+			summary, err = client.Converse(ctx, claude.Request{
+				System:    system,
+				Messages:  []claude.Message{claude.UserMessage(piece)},
+				MaxTokens: maxTokens,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("summarizing section %d/%d: %w", i+1, len(pieces), err)
+			}
+			if cache != nil {
+				if err := cache.put(system, piece, summary); err != nil {
+					log.Printf("warning: writing summary cache: %v", err)
+				}
+			}
+		}
+		level = append(level, &summaryNode{
+			Title:   fmt.Sprintf("Section %d", i+1),
+			Summary: summary,
+		})
+		bar.Done()
+	}
+
+	depth := 0
+	for len(level) > 1 {
+		depth++
+		var next []*summaryNode
+		for start := 0; start < len(level); start += hierFanIn {
+			end := start + hierFanIn
+			if end > len(level) {
+				end = len(level)
+			}
+			group := level[start:end]
+			var sb strings.Builder
+			for _, child := range group {
+				sb.WriteString("## " + child.Title + "\n\n" + child.Summary + "\n\n")
+			}
+			summary, err := client.Converse(ctx, claude.Request{
+				System:    system + " The input consists of summaries of consecutive parts of a larger document; merge them into one coherent summary of the whole span.",
+				Messages:  []claude.Message{claude.UserMessage(sb.String())},
+				MaxTokens: maxTokens,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("merging level %d: %w", depth, err)
+			}
+			next = append(next, &summaryNode{
+				Title:    fmt.Sprintf("%s through %s", group[0].Title, group[len(group)-1].Title),
+				Summary:  summary,
+				Children: group,
+			})
+		}
+		level = next
+	}
+	root := level[0]
+	root.Title = "Document"
+	return root, nil
+}
+
+// writeOutline renders the summary tree as an indented Markdown outline, root
+// first, so intermediate summaries are not lost.
+func writeOutline(sb *strings.Builder, node *summaryNode, depth int) {
+	sb.WriteString(strings.Repeat("#", depth+1) + " " + node.Title + "\n\n")
+	sb.WriteString(node.Summary + "\n\n")
+	for _, child := range node.Children {
+		writeOutline(sb, child, depth+1)
+	}
+}
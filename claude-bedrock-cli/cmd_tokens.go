@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"strings"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+)
+
+// runTokens estimates token counts for text and images without calling
+// the API, and can verify the text estimate against the count Bedrock
+// actually reports, folding the difference into future estimates.
+func runTokens(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("tokens", flag.ExitOnError)
+	model := fs.String("model", "", "Bedrock model ID the estimate is for")
+	region := fs.String("region", "", "AWS region (only used with -measure)")
+	file := fs.String("file", "", "text to estimate (default: stdin, unless a prompt argument is given)")
+	imagePath := fs.String("image", "", "estimate an image by its dimensions instead of text")
+	measure := fs.Bool("measure", false, "send the text with max_tokens=1 and compare against the real count")
+	fs.Parse(args)
+
+	cal, err := claude.LoadCalibration()
+	if err != nil {
+		return err
+	}
+	modelID := *model
+	if modelID == "" {
+		modelID = claude.DefaultModelID
+	}
+
+	if *imagePath != "" {
+		f, err := os.Open(*imagePath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		cfg, _, err := image.DecodeConfig(f)
+		if err != nil {
+			return fmt.Errorf("tokens: decoding %s: %w", *imagePath, err)
+		}
+		fmt.Printf("%d tokens (estimated, %dx%d image)\n",
+			claude.EstimateImageTokens(cfg.Width, cfg.Height), cfg.Width, cfg.Height)
+		return nil
+	}
+
+	var text string
+	if fs.NArg() > 0 {
+		text = strings.Join(fs.Args(), " ")
+	} else if text, err = readInput(*file); err != nil {
+		return err
+	}
+
+	estimate := claude.EstimateTextTokens(modelID, text)
+	calibrated := int(float64(estimate)*cal.Factor(modelID) + 0.5)
+	fmt.Printf("%d tokens (estimated for %s)\n", calibrated, modelID)
+
+	if !*measure {
+		return nil
+	}
+	client, err := claude.NewClient(ctx, *region, *model)
+	if err != nil {
+		return err
+	}
+	_, usage, err := client.ConverseUsage(ctx, claude.Request{
+		Messages:  []claude.Message{claude.UserMessage(text)},
+		MaxTokens: 1,
+	})
+	if err != nil {
+		return err
+	}
+	actual := int(usage.InputTokens)
+	fmt.Printf("%d tokens (reported by Bedrock)\n", actual)
+	cal.Observe(modelID, estimate, actual)
+	if err := cal.Save(); err != nil {
+		return fmt.Errorf("tokens: saving calibration: %w", err)
+	}
+	fmt.Printf("calibration factor for %s is now %.3f\n", modelID, cal.Factor(modelID))
+	return nil
+}
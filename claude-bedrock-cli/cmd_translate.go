@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/chunk"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+)
+
+// translateChunkChars keeps each translation request comfortably inside the
+// model's context window while leaving room for the translated output.
+const translateChunkChars = 12000
+
+func runTranslate(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("translate", flag.ExitOnError)
+	file := fs.String("file", "-", "input file (- for stdin)")
+	target := fs.String("target", "", "target language, e.g. \"German\" (required)")
+	source := fs.String("source", "auto", "source language, or \"auto\" to detect")
+	glossaryPath := fs.String("glossary", "", "glossary file with one \"term = translation\" pair per line")
+	model := fs.String("model", "", "Bedrock model ID")
+	region := fs.String("region", "", "AWS region")
+	maxTokens := fs.Int("max-tokens", 4096, "max output tokens per chunk")
+	fs.Parse(args)
+
+	if *target == "" {
+		return fmt.Errorf("translate: -target is required")
+	}
+	text, err := readInput(*file)
+	if err != nil {
+		return err
+	}
+	glossary, err := loadGlossary(*glossaryPath)
+	if err != nil {
+		return err
+	}
+
+	client, err := claude.NewClient(ctx, *region, *model)
+	if err != nil {
+		return err
+	}
+
+	system := translateSystemPrompt(*source, *target, glossary)
+	for i, piece := range chunk.Split(text, translateChunkChars) {
+		if i > 0 {
+			fmt.Println()
+		}
+		_, err := client.ConverseStream(ctx, claude.Request{
+			System:    system,
+			Messages:  []claude.Message{claude.UserMessage(piece)},
+			MaxTokens: int32(*maxTokens),
+		}, func(delta string) { fmt.Print(delta) })
+		if err != nil {
+			return err
+		}
+	}
+	fmt.Println()
+	return nil
+}
+
+func translateSystemPrompt(source, target string, glossary map[string]string) string {
+	var sb strings.Builder
+	if source == "" || source == "auto" {
+		sb.WriteString("You are a professional translator. Detect the source language of the text, then translate it into " + target + ".")
+	} else {
+		sb.WriteString("You are a professional translator. Translate the following " + source + " text into " + target + ".")
+	}
+	sb.WriteString(" Preserve the original formatting exactly: paragraph breaks, lists, headings, code blocks, and inline markup.")
+	sb.WriteString(" Output only the translation, with no commentary.")
+	if len(glossary) > 0 {
+		sb.WriteString("\n\nAlways use these domain-specific translations:\n")
+		for term, translation := range glossary {
+			sb.WriteString(fmt.Sprintf("- %q -> %q\n", term, translation))
+		}
+	}
+	return sb.String()
+}
+
+// loadGlossary parses a glossary file of "term = translation" lines. Blank
+// lines and lines starting with # are ignored.
+func loadGlossary(path string) (map[string]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	glossary := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		term, translation, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("glossary %s: malformed line %q", path, line)
+		}
+		glossary[strings.TrimSpace(term)] = strings.TrimSpace(translation)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return glossary, nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/audit"
+)
+
+// runUsageReport aggregates the local audit database into token and
+// dollar totals per day, model, or command, with optional CSV export.
+func runUsageReport(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("usage-report", flag.ExitOnError)
+	by := fs.String("by", "day", "grouping: day, model, or command")
+	since := fs.Duration("since", 0, "only count invocations newer than this (e.g. 720h)")
+	db := fs.String("db", "", "audit database path (default: ~/.claude-bedrock-cli/audit.db)")
+	csvOut := fs.Bool("csv", false, "emit CSV instead of a table")
+	fs.Parse(args)
+
+	alog, err := audit.Open(*db)
+	if err != nil {
+		return err
+	}
+	defer alog.Close()
+
+	var lower time.Time
+	if *since > 0 {
+		lower = time.Now().Add(-*since)
+	}
+	rows, err := alog.Aggregate(ctx, *by, lower)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		fmt.Println("no audited invocations (set CLAUDE_CLI_AUDIT=1 to enable auditing)")
+		return nil
+	}
+
+	var total audit.UsageRow
+	for _, row := range rows {
+		total.Invocations += row.Invocations
+		total.InputTokens += row.InputTokens
+		total.OutputTokens += row.OutputTokens
+		total.CostUSD += row.CostUSD
+	}
+
+	if *csvOut {
+		w := csv.NewWriter(os.Stdout)
+		w.Write([]string{*by, "invocations", "input_tokens", "output_tokens", "cost_usd"})
+		for _, row := range rows {
+			w.Write([]string{
+				row.Key,
+				strconv.FormatInt(row.Invocations, 10),
+				strconv.FormatInt(row.InputTokens, 10),
+				strconv.FormatInt(row.OutputTokens, 10),
+				strconv.FormatFloat(row.CostUSD, 'f', 4, 64),
+			})
+		}
+		w.Flush()
+		return w.Error()
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 2, 8, 2, ' ', 0)
+	fmt.Fprintf(w, "%s\tCALLS\tIN\tOUT\tCOST\n", headerFor(*by))
+	for _, row := range rows {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t$%.4f\n",
+			row.Key, row.Invocations, row.InputTokens, row.OutputTokens, row.CostUSD)
+	}
+	fmt.Fprintf(w, "total\t%d\t%d\t%d\t$%.4f\n",
+		total.Invocations, total.InputTokens, total.OutputTokens, total.CostUSD)
+	return w.Flush()
+}
+
+func headerFor(by string) string {
+	switch by {
+	case "model":
+		return "MODEL"
+	case "command":
+		return "COMMAND"
+	default:
+		return "DAY"
+	}
+}
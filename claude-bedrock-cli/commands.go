@@ -0,0 +1,74 @@
+package main
+
+import "context"
+
+// command is one subcommand in the CLI tree: its name, the one-line summary
+// usage prints, and its entry point. Global options (-quiet) are parsed in
+// main before dispatch; everything else belongs to the command's own
+// flag.FlagSet.
+type command struct {
+	name    string
+	summary string
+	// hidden commands are callable but not listed: internal hooks such as
+	// the completion callback.
+	hidden bool
+	run    func(ctx context.Context, args []string) error
+}
+
+// commands is the subcommand tree, alphabetical. The usage text and shell
+// completion word lists are generated from it, so adding a command here is
+// the whole registration.
+var commands = []command{
+	{name: "agent", summary: "Answer a prompt with tool use under a permission policy", run: runAgent},
+	{name: "ask", summary: "Answer a question about a long document using relevant chunks", run: runAsk},
+	{name: "chat", summary: "Interactive conversation with branching and persistent sessions", run: runChat},
+	{name: "completion", summary: "Print a bash, zsh, or fish completion script", run: runCompletion},
+	// __complete's run is wired in init below: runCompleteHook walks the
+	// registry for the "commands" word list, which would otherwise be an
+	// initialization cycle.
+	{name: "__complete", hidden: true},
+	{name: "diff-models", summary: "Run one prompt on two models and diff the answers", run: runDiffModels},
+	{name: "generate-image", summary: "Generate images with a Bedrock image model, with optional prompt rewriting", run: runGenerateImage},
+	{name: "guardrail-check", summary: "Check text against a guardrail without invoking a model", run: runGuardrailCheck},
+	{name: "history", summary: "Query the local invocation audit log", run: runHistory},
+	{name: "invocation-logs", summary: "Find the server-side Bedrock invocation log entry for a request ID", run: runInvocationLogs},
+	{name: "orchestrate", summary: "Answer a task with a relay of role-specialized agents", run: runOrchestrate},
+	{name: "patch", summary: "Ask for code changes as a unified diff and apply approved hunks", run: runPatch},
+	{name: "pipeline", summary: "Execute a YAML-defined multi-stage prompt chain", run: runPipeline},
+	{name: "profiles", summary: "Create application inference profiles and name them for -model", run: runProfiles},
+	{name: "prompts", summary: "List, save, and run prompts from the saved prompt library", run: runPrompts},
+	{name: "quotas", summary: "Show Bedrock service quotas and recent throttle counts", run: runQuotas},
+	{name: "run", summary: "One-shot prompt, optionally with best-of-N sampling", run: runRun},
+	{name: "schedule", summary: "Run configured prompts and pipelines on cron schedules", run: runSchedule},
+	{name: "serve", summary: "HTTP server exposing chat with per-tenant auth and quotas", run: runServe},
+	{name: "sessions", summary: "List, search, export, import, and purge persisted chat sessions", run: runSessions},
+	{name: "summarize", summary: "Summarize a document using a style preset", run: runSummarize},
+	{name: "tokens", summary: "Estimate token counts for text and images", run: runTokens},
+	{name: "translate", summary: "Translate a document with source-language auto-detection", run: runTranslate},
+	{name: "usage-report", summary: "Aggregate the audit log into token and dollar totals", run: runUsageReport},
+}
+
+func init() {
+	lookupCommand("__complete").run = runCompleteHook
+}
+
+// lookupCommand resolves a name to its command, or nil.
+func lookupCommand(name string) *command {
+	for i := range commands {
+		if commands[i].name == name {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
+// commandNames lists the visible subcommand names in registry order.
+func commandNames() []string {
+	names := make([]string, 0, len(commands))
+	for _, cmd := range commands {
+		if !cmd.hidden {
+			names = append(names, cmd.name)
+		}
+	}
+	return names
+}
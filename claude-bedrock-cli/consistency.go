@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+)
+
+var answerTagRE = regexp.MustCompile(`(?s)<answer>\s*(.*?)\s*</answer>`)
+
+// selfConsistency samples k answers at a moderate temperature, extracts each
+// final answer from an <answer> tag, and returns the majority-vote result
+// along with its vote share as a confidence measure.
+func selfConsistency(ctx context.Context, client *claude.Client, prompt string, k int, maxTokens int32, verbose bool) (string, float64, error) {
+	system := "Work through the problem step by step, then give your final answer inside <answer></answer> tags. The tag must contain only the answer itself, with no explanation."
+
+	answers := make([]string, k)
+	errs := make([]error, k)
+	var wg sync.WaitGroup
+	for i := 0; i < k; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			answers[i], errs[i] = client.Converse(ctx, claude.Request{
+				System:      system,
+				Messages:    []claude.Message{claude.UserMessage(prompt)},
+				MaxTokens:   maxTokens,
+				Temperature: aws.Float32(0.7),
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	// Votes are keyed by a normalized form; the first spelling seen is kept
+	// for display.
+	votes := make(map[string]int)
+	display := make(map[string]string)
+	total := 0
+	for i := 0; i < k; i++ {
+		if errs[i] != nil {
+			fmt.Fprintf(os.Stderr, "warning: sample %d failed: %v\n", i+1, errs[i])
+			continue
+		}
+		m := answerTagRE.FindStringSubmatch(answers[i])
+		if m == nil {
+			fmt.Fprintf(os.Stderr, "warning: sample %d had no <answer> tag\n", i+1)
+			continue
+		}
+		norm := normalizeAnswer(m[1])
+		if _, seen := display[norm]; !seen {
+			display[norm] = m[1]
+		}
+		votes[norm]++
+		total++
+	}
+	if total == 0 {
+		return "", 0, fmt.Errorf("self-consistency: no usable answers out of %d samples", k)
+	}
+
+	bestNorm, bestVotes := "", 0
+	for norm, n := range votes {
+		if n > bestVotes {
+			bestNorm, bestVotes = norm, n
+		}
+	}
+	confidence := float64(bestVotes) / float64(total)
+	if verbose {
+		for norm, n := range votes {
+			fmt.Fprintf(os.Stderr, "%2d votes: %s\n", n, display[norm])
+		}
+	}
+	return display[bestNorm], confidence, nil
+}
+
+// normalizeAnswer canonicalizes an extracted answer so equivalent spellings
+// vote together.
+func normalizeAnswer(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = strings.Join(strings.Fields(s), " ")
+	s = strings.Trim(s, ".,;:")
+	return s
+}
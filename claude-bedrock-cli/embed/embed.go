@@ -0,0 +1,84 @@
+// Package embed generates text embeddings with Amazon Titan models on
+// Bedrock, used for relevance ranking of document chunks.
+package embed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+)
+
+// DefaultModelID is the Titan text embeddings model used when none is given.
+const DefaultModelID = "amazon.titan-embed-text-v2:0"
+
+// Client generates embeddings through the Bedrock Runtime InvokeModel API.
+type Client struct {
+	rt      *bedrockruntime.Client
+	ModelID string
+}
+
+// NewClient loads the default AWS configuration for the given region and
+// returns an embeddings client bound to modelID (DefaultModelID when empty).
+func NewClient(ctx context.Context, region, modelID string) (*Client, error) {
+	var opts []func(*awsconfig.LoadOptions) error
+	if region != "" {
+		opts = append(opts, awsconfig.WithRegion(region))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	if modelID == "" {
+		modelID = DefaultModelID
+	}
+	return &Client{
+		rt:      bedrockruntime.NewFromConfig(cfg),
+		ModelID: modelID,
+	}, nil
+}
+
+// Embed returns the embedding vector for text.
+func (c *Client) Embed(ctx context.Context, text string) ([]float64, error) {
+	body, err := json.Marshal(map[string]string{"inputText": text})
+	if err != nil {
+		return nil, err
+	}
+	out, err := c.rt.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
+		ModelId:     aws.String(c.ModelID),
+		ContentType: aws.String("application/json"),
+		Body:        body,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("embed: %w", err)
+	}
+	var resp struct {
+		Embedding []float64 `json:"embedding"`
+	}
+	if err := json.Unmarshal(out.Body, &resp); err != nil {
+		return nil, fmt.Errorf("embed: decoding response: %w", err)
+	}
+	return resp.Embedding, nil
+}
+
+// Cosine returns the cosine similarity of two vectors, or 0 when either is
+// empty or their lengths differ.
+func Cosine(a, b []float64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// decodeText converts raw file bytes to a UTF-8 string: BOMs are stripped,
+// UTF-16 (with or without a BOM) and Latin-1 are converted, and CRLF/CR
+// line endings are normalized to LF. Valid UTF-8 passes through untouched
+// beyond BOM and line-ending handling.
+func decodeText(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		data = data[3:]
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		return normalizeNewlines(decodeUTF16(data[2:], false))
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		return normalizeNewlines(decodeUTF16(data[2:], true))
+	}
+	// BOM-less UTF-16 shows up as NUL bytes interleaved with ASCII; the
+	// position of the NULs tells the byte order apart.
+	if i := bytes.IndexByte(data, 0); i >= 0 && len(data) >= 2 {
+		return normalizeNewlines(decodeUTF16(data, i%2 == 0))
+	}
+	if !utf8.Valid(data) {
+		// Not UTF-8 and not UTF-16: assume Latin-1, where every byte maps
+		// directly to the code point of the same value.
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+		return normalizeNewlines(string(runes))
+	}
+	return normalizeNewlines(string(data))
+}
+
+// decodeUTF16 interprets data as UTF-16 code units in the given byte
+// order; a dangling odd byte is dropped.
+func decodeUTF16(data []byte, bigEndian bool) string {
+	units := make([]uint16, 0, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		if bigEndian {
+			units = append(units, uint16(data[i])<<8|uint16(data[i+1]))
+		} else {
+			units = append(units, uint16(data[i])|uint16(data[i+1])<<8)
+		}
+	}
+	return string(utf16.Decode(units))
+}
+
+// normalizeNewlines rewrites CRLF and bare CR line endings to LF.
+func normalizeNewlines(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(s, "\r", "\n")
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+	"unicode/utf16"
+)
+
+func encodeUTF16(s string, bigEndian bool, bom bool) []byte {
+	var out []byte
+	if bom {
+		if bigEndian {
+			out = append(out, 0xFE, 0xFF)
+		} else {
+			out = append(out, 0xFF, 0xFE)
+		}
+	}
+	for _, u := range utf16.Encode([]rune(s)) {
+		if bigEndian {
+			out = append(out, byte(u>>8), byte(u))
+		} else {
+			out = append(out, byte(u), byte(u>>8))
+		}
+	}
+	return out
+}
+
+func TestDecodeTextPassesUTF8Through(t *testing.T) {
+	if got := decodeText([]byte("héllo wörld\n")); got != "héllo wörld\n" {
+		t.Errorf("decodeText = %q", got)
+	}
+}
+
+func TestDecodeTextStripsUTF8BOM(t *testing.T) {
+	if got := decodeText([]byte{0xEF, 0xBB, 0xBF, 'h', 'i'}); got != "hi" {
+		t.Errorf("decodeText = %q, want %q", got, "hi")
+	}
+}
+
+func TestDecodeTextUTF16(t *testing.T) {
+	const want = "héllo"
+	cases := map[string][]byte{
+		"LE with BOM":    encodeUTF16(want, false, true),
+		"BE with BOM":    encodeUTF16(want, true, true),
+		"LE without BOM": encodeUTF16(want, false, false),
+		"BE without BOM": encodeUTF16(want, true, false),
+	}
+	for name, data := range cases {
+		if got := decodeText(data); got != want {
+			t.Errorf("%s: decodeText = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestDecodeTextLatin1(t *testing.T) {
+	// "café" in Latin-1: é is the single byte 0xE9, invalid as UTF-8.
+	if got := decodeText([]byte{'c', 'a', 'f', 0xE9}); got != "café" {
+		t.Errorf("decodeText = %q, want %q", got, "café")
+	}
+}
+
+func TestDecodeTextNormalizesNewlines(t *testing.T) {
+	if got := decodeText([]byte("a\r\nb\rc\n")); got != "a\nb\nc\n" {
+		t.Errorf("decodeText = %q, want %q", got, "a\nb\nc\n")
+	}
+}
@@ -0,0 +1,87 @@
+// Package events emits job lifecycle events to Amazon EventBridge so
+// other AWS automation can react to model jobs without polling.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+)
+
+// Source is the event source field on every emitted event.
+const Source = "claude-bedrock-cli"
+
+// JobDetail is the JSON detail payload of a job lifecycle event. Token
+// and cost fields are zero for Started events and for jobs whose usage is
+// not tracked.
+type JobDetail struct {
+	Job          string  `json:"job"`
+	Status       string  `json:"status"` // started, succeeded, failed
+	Model        string  `json:"model,omitempty"`
+	Error        string  `json:"error,omitempty"`
+	InputTokens  int32   `json:"input_tokens,omitempty"`
+	OutputTokens int32   `json:"output_tokens,omitempty"`
+	CostUSD      float64 `json:"cost_usd,omitempty"`
+	DurationMS   int64   `json:"duration_ms,omitempty"`
+}
+
+// Emitter publishes events to one EventBridge bus.
+type Emitter struct {
+	client *eventbridge.Client
+	bus    string
+}
+
+// NewEmitter builds an Emitter for the named bus; empty means the
+// account's default bus.
+func NewEmitter(ctx context.Context, region, bus string) (*Emitter, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+	if err != nil {
+		return nil, err
+	}
+	return &Emitter{client: eventbridge.NewFromConfig(cfg), bus: bus}, nil
+}
+
+// EmitJob publishes one job lifecycle event with detail-type
+// "Job Started", "Job Succeeded", or "Job Failed" according to the
+// detail's status.
+func (e *Emitter) EmitJob(ctx context.Context, detail JobDetail) error {
+	payload, err := json.Marshal(detail)
+	if err != nil {
+		return err
+	}
+	detailType := "Job " + title(detail.Status)
+	entry := types.PutEventsRequestEntry{
+		Source:     aws.String(Source),
+		DetailType: aws.String(detailType),
+		Detail:     aws.String(string(payload)),
+	}
+	if e.bus != "" {
+		entry.EventBusName = aws.String(e.bus)
+	}
+	out, err := e.client.PutEvents(ctx, &eventbridge.PutEventsInput{
+		Entries: []types.PutEventsRequestEntry{entry},
+	})
+	if err != nil {
+		return fmt.Errorf("eventbridge put: %w", err)
+	}
+	if out.FailedEntryCount > 0 && len(out.Entries) > 0 && out.Entries[0].ErrorMessage != nil {
+		return fmt.Errorf("eventbridge put: %s", *out.Entries[0].ErrorMessage)
+	}
+	return nil
+}
+
+// title capitalizes a one-word status for the detail-type.
+func title(s string) string {
+	if s == "" {
+		return s
+	}
+	if s[0] >= 'a' && s[0] <= 'z' {
+		return string(s[0]-'a'+'A') + s[1:]
+	}
+	return s
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+)
+
+// groundedness scoring is a local hallucination guard for summaries: each
+// summary paragraph is scored by what fraction of its word trigrams occur in
+// the source text. Abstractive rewording lowers the score, so it is a
+// screening signal rather than proof — but a paragraph sharing almost no
+// phrasing with the input deserves a second look.
+
+// groundednessWarnBelow is the score under which a paragraph is flagged as
+// possibly unsupported by the source.
+const groundednessWarnBelow = 0.2
+
+// paragraphScore is one summary paragraph with its source-overlap score in
+// [0, 1].
+type paragraphScore struct {
+	Paragraph string
+	Score     float64
+}
+
+// groundednessScores splits the summary into paragraphs and scores each
+// against the source.
+func groundednessScores(source, summary string) []paragraphScore {
+	sourceGrams := ngramSet(source, 3)
+	// Trigrams of a short source are sparse; include bigrams so brief
+	// inputs do not flag everything.
+	for gram := range ngramSet(source, 2) {
+		sourceGrams[gram] = struct{}{}
+	}
+	var scores []paragraphScore
+	for _, para := range strings.Split(summary, "\n\n") {
+		para = strings.TrimSpace(para)
+		if para == "" {
+			continue
+		}
+		scores = append(scores, paragraphScore{
+			Paragraph: para,
+			Score:     overlapScore(sourceGrams, para),
+		})
+	}
+	return scores
+}
+
+// overlapScore is the fraction of the paragraph's trigrams (bigrams for
+// very short paragraphs) found in the source set.
+func overlapScore(sourceGrams map[string]struct{}, para string) float64 {
+	n := 3
+	if len(normalizeWords(para)) < 3 {
+		n = 2
+	}
+	grams := ngramSet(para, n)
+	if len(grams) == 0 {
+		return 1
+	}
+	hits := 0
+	for gram := range grams {
+		if _, ok := sourceGrams[gram]; ok {
+			hits++
+		}
+	}
+	return float64(hits) / float64(len(grams))
+}
+
+// ngramSet returns the set of n-word sequences in text, case-folded and
+// stripped of punctuation so formatting differences do not break matches.
+func ngramSet(text string, n int) map[string]struct{} {
+	words := normalizeWords(text)
+	grams := make(map[string]struct{})
+	for i := 0; i+n <= len(words); i++ {
+		grams[strings.Join(words[i:i+n], " ")] = struct{}{}
+	}
+	return grams
+}
+
+func normalizeWords(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	return fields
+}
@@ -0,0 +1,111 @@
+// Package grpcserver exposes the chat API as a gRPC bidirectional stream.
+// The wire contract lives in proto/chat.proto; regenerate the stubs in
+// chatpb with go generate after editing it.
+package grpcserver
+
+//go:generate protoc --go_out=. --go_opt=module=github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/grpcserver --go-grpc_out=. --go-grpc_opt=module=github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/grpcserver proto/chat.proto
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+
+	"google.golang.org/grpc"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/grpcserver/chatpb"
+)
+
+// Server implements chatpb.ChatServiceServer on top of a claude.Client, the
+// same Converse abstractions backing the HTTP server.
+type Server struct {
+	chatpb.UnimplementedChatServiceServer
+
+	client       *claude.Client
+	defaultModel string
+	maxTokens    int32
+}
+
+// New builds a gRPC chat server around an existing claude.Client.
+func New(client *claude.Client, maxTokens int32) *Server {
+	return &Server{
+		client:       client,
+		defaultModel: client.ModelID,
+		maxTokens:    maxTokens,
+	}
+}
+
+// Chat handles the bidirectional stream: each received ChatRequest is one
+// turn, answered with delta events and a terminating usage event. Requests
+// on the same stream run sequentially, preserving ordering for callers that
+// hold a conversation over one connection.
+func (s *Server) Chat(stream chatpb.ChatService_ChatServer) error {
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			// io.EOF from Recv means the client finished sending.
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if err := s.serveTurn(stream, req); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) serveTurn(stream chatpb.ChatService_ChatServer, req *chatpb.ChatRequest) error {
+	if len(req.Messages) == 0 {
+		return stream.Send(&chatpb.ChatEvent{Event: &chatpb.ChatEvent_Error{Error: "messages must not be empty"}})
+	}
+	model := req.Model
+	if model == "" {
+		model = s.defaultModel
+	}
+	creq := claude.Request{System: req.System, MaxTokens: req.MaxTokens}
+	if creq.MaxTokens <= 0 || creq.MaxTokens > s.maxTokens {
+		creq.MaxTokens = s.maxTokens
+	}
+	for _, m := range req.Messages {
+		if m.Role == "assistant" {
+			creq.Messages = append(creq.Messages, claude.AssistantMessage(m.Text))
+		} else {
+			creq.Messages = append(creq.Messages, claude.UserMessage(m.Text))
+		}
+	}
+
+	client := s.client.WithModel(model)
+
+	var sendErr error
+	_, usage, err := client.ConverseStreamUsage(stream.Context(), creq, func(delta string) {
+		if sendErr != nil {
+			return
+		}
+		sendErr = stream.Send(&chatpb.ChatEvent{Event: &chatpb.ChatEvent_Delta{Delta: delta}})
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	if err != nil {
+		return stream.Send(&chatpb.ChatEvent{Event: &chatpb.ChatEvent_Error{Error: err.Error()}})
+	}
+	return stream.Send(&chatpb.ChatEvent{Event: &chatpb.ChatEvent_Usage{Usage: &chatpb.Usage{
+		InputTokens:  usage.InputTokens,
+		OutputTokens: usage.OutputTokens,
+	}}})
+}
+
+// ListenAndServe runs the gRPC server until the listener fails.
+func (s *Server) ListenAndServe(addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("grpc: %w", err)
+	}
+	srv := grpc.NewServer()
+	chatpb.RegisterChatServiceServer(srv, s)
+	log.Printf("grpc: listening on %s", addr)
+	return srv.Serve(lis)
+}
@@ -0,0 +1,164 @@
+// Package imagegen generates images with Bedrock image models through the
+// InvokeModel API, covering the Titan/Nova image generators and Stability
+// models behind one request shape.
+package imagegen
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+)
+
+// DefaultModelID is the image model used when none is given.
+const DefaultModelID = "amazon.titan-image-generator-v2:0"
+
+// Client generates images through the Bedrock Runtime InvokeModel API.
+type Client struct {
+	rt      *bedrockruntime.Client
+	ModelID string
+}
+
+// NewClient loads the default AWS configuration for the given region and
+// returns an image client bound to modelID (DefaultModelID when empty).
+func NewClient(ctx context.Context, region, modelID string) (*Client, error) {
+	var opts []func(*awsconfig.LoadOptions) error
+	if region != "" {
+		opts = append(opts, awsconfig.WithRegion(region))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	if modelID == "" {
+		modelID = DefaultModelID
+	}
+	return &Client{
+		rt:      bedrockruntime.NewFromConfig(cfg),
+		ModelID: modelID,
+	}, nil
+}
+
+// Options tunes a generation request; zero values apply each model's
+// defaults.
+type Options struct {
+	NegativePrompt string
+	Width, Height  int
+	// Seed makes generation repeatable on models that honour it; -1 leaves
+	// it unset.
+	Seed int64
+	// Count asks for this many images in one call; 0 means one.
+	Count int
+}
+
+// Generate returns Count PNG images for the prompt. The request body is
+// shaped for the model family: Titan/Nova task form, or Stability text
+// prompts.
+func (c *Client) Generate(ctx context.Context, prompt string, opts Options) ([][]byte, error) {
+	if opts.Count <= 0 {
+		opts.Count = 1
+	}
+	body, decode := c.requestFor(prompt, opts)
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	out, err := c.rt.InvokeModel(ctx, &bedrockruntime.InvokeModelInput{
+		ModelId:     aws.String(c.ModelID),
+		ContentType: aws.String("application/json"),
+		Body:        payload,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("imagegen: %w", err)
+	}
+	encoded, err := decode(out.Body)
+	if err != nil {
+		return nil, err
+	}
+	images := make([][]byte, 0, len(encoded))
+	for _, img := range encoded {
+		data, err := base64.StdEncoding.DecodeString(img)
+		if err != nil {
+			return nil, fmt.Errorf("imagegen: decoding image: %w", err)
+		}
+		images = append(images, data)
+	}
+	return images, nil
+}
+
+// requestFor builds the model family's request body and the matching
+// response decoder, which returns the base64-encoded images.
+func (c *Client) requestFor(prompt string, opts Options) (any, func([]byte) ([]string, error)) {
+	if strings.Contains(c.ModelID, "stability.") {
+		body := map[string]any{
+			"text_prompts": []map[string]any{{"text": prompt, "weight": 1.0}},
+			"samples":      opts.Count,
+		}
+		if opts.NegativePrompt != "" {
+			body["text_prompts"] = append(body["text_prompts"].([]map[string]any),
+				map[string]any{"text": opts.NegativePrompt, "weight": -1.0})
+		}
+		if opts.Width > 0 {
+			body["width"] = opts.Width
+		}
+		if opts.Height > 0 {
+			body["height"] = opts.Height
+		}
+		if opts.Seed >= 0 {
+			body["seed"] = opts.Seed
+		}
+		return body, func(data []byte) ([]string, error) {
+			var resp struct {
+				Artifacts []struct {
+					Base64 string `json:"base64"`
+				} `json:"artifacts"`
+			}
+			if err := json.Unmarshal(data, &resp); err != nil {
+				return nil, fmt.Errorf("imagegen: %w", err)
+			}
+			images := make([]string, 0, len(resp.Artifacts))
+			for _, a := range resp.Artifacts {
+				images = append(images, a.Base64)
+			}
+			return images, nil
+		}
+	}
+
+	params := map[string]any{"text": prompt}
+	if opts.NegativePrompt != "" {
+		params["negativeText"] = opts.NegativePrompt
+	}
+	config := map[string]any{"numberOfImages": opts.Count}
+	if opts.Width > 0 {
+		config["width"] = opts.Width
+	}
+	if opts.Height > 0 {
+		config["height"] = opts.Height
+	}
+	if opts.Seed >= 0 {
+		config["seed"] = opts.Seed
+	}
+	body := map[string]any{
+		"taskType":              "TEXT_IMAGE",
+		"textToImageParams":     params,
+		"imageGenerationConfig": config,
+	}
+	return body, func(data []byte) ([]string, error) {
+		var resp struct {
+			Images []string `json:"images"`
+			Error  string   `json:"error"`
+		}
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, fmt.Errorf("imagegen: %w", err)
+		}
+		if resp.Error != "" {
+			return nil, fmt.Errorf("imagegen: %s", resp.Error)
+		}
+		return resp.Images, nil
+	}
+}
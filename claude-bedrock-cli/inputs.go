@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// fileFlags collects repeated -file flags; each value may be a literal
+// path, "-" for stdin, or a glob pattern.
+type fileFlags []string
+
+func (f *fileFlags) String() string { return strings.Join(*f, ",") }
+
+func (f *fileFlags) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// expandFiles resolves each pattern to concrete paths, expanding glob
+// metacharacters and erroring on patterns that match nothing, since a
+// silently empty context is almost always a typo.
+func expandFiles(patterns []string) ([]string, error) {
+	var paths []string
+	for _, pattern := range patterns {
+		if pattern == "-" || !strings.ContainsAny(pattern, "*?[") {
+			paths = append(paths, pattern)
+			continue
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("bad glob %q: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files match %q", pattern)
+		}
+		paths = append(paths, matches...)
+	}
+	return paths, nil
+}
+
+// readDocuments reads every resolved file and renders them for a prompt.
+// Archives expand into one document per entry. A single document keeps
+// the bare <document> wrapper; multiple documents get a manifest plus
+// per-file name labels so answers can reference specific files.
+func readDocuments(patterns []string) (string, error) {
+	paths, err := expandFiles(patterns)
+	if err != nil {
+		return "", err
+	}
+	var docs []archiveEntry
+	for _, path := range paths {
+		if isArchive(path) {
+			entries, err := readArchive(path, nil, nil)
+			if err != nil {
+				return "", err
+			}
+			docs = append(docs, entries...)
+			continue
+		}
+		text, err := readInput(path)
+		if err != nil {
+			return "", err
+		}
+		docs = append(docs, archiveEntry{Name: filepath.Base(path), Text: text})
+	}
+	if len(docs) == 1 {
+		return "<document>\n" + docs[0].Text + "\n</document>\n", nil
+	}
+	var sb strings.Builder
+	sb.WriteString("<manifest>\n")
+	for _, doc := range docs {
+		sb.WriteString(doc.Name + "\n")
+	}
+	sb.WriteString("</manifest>\n")
+	for _, doc := range docs {
+		fmt.Fprintf(&sb, "<document name=%q>\n%s\n</document>\n", doc.Name, doc.Text)
+	}
+	return sb.String(), nil
+}
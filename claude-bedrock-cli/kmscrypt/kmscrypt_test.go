@@ -0,0 +1,77 @@
+package kmscrypt
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+)
+
+// fakeKMS wraps data keys by XOR with a fixed mask: enough to prove the
+// envelope carries the wrapped key through Encrypt and back into Decrypt.
+type fakeKMS struct{}
+
+const keyMask = 0x5a
+
+func xorMask(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[i] = b[i] ^ keyMask
+	}
+	return out
+}
+
+func (fakeKMS) GenerateDataKey(ctx context.Context, in *kms.GenerateDataKeyInput, opts ...func(*kms.Options)) (*kms.GenerateDataKeyOutput, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return &kms.GenerateDataKeyOutput{Plaintext: key, CiphertextBlob: xorMask(key)}, nil
+}
+
+func (fakeKMS) Decrypt(ctx context.Context, in *kms.DecryptInput, opts ...func(*kms.Options)) (*kms.DecryptOutput, error) {
+	if len(in.CiphertextBlob) == 0 {
+		return nil, fmt.Errorf("empty CiphertextBlob")
+	}
+	return &kms.DecryptOutput{Plaintext: xorMask(in.CiphertextBlob)}, nil
+}
+
+func TestRoundTrip(t *testing.T) {
+	c := &Cipher{kms: fakeKMS{}, keyID: "alias/test"}
+	plaintext := []byte(`{"id":"20260829-120000","branches":{"main":[]}}`)
+
+	sealed, err := c.Encrypt(context.Background(), plaintext)
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	if bytes.Contains(sealed, plaintext) {
+		t.Fatal("envelope contains the plaintext")
+	}
+	if !IsEnvelope(sealed) {
+		t.Fatal("IsEnvelope = false for a sealed envelope")
+	}
+
+	got, err := c.Decrypt(context.Background(), sealed)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Fatalf("round trip mismatch: got %q, want %q", got, plaintext)
+	}
+}
+
+func TestDecryptRejectsGarbage(t *testing.T) {
+	c := &Cipher{kms: fakeKMS{}, keyID: "alias/test"}
+	if _, err := c.Decrypt(context.Background(), []byte("not an envelope")); err == nil {
+		t.Fatal("Decrypt accepted non-envelope data")
+	}
+}
+
+func TestIsEnvelopeRejectsPlainJSON(t *testing.T) {
+	if IsEnvelope([]byte(`{"id":"x","branches":{}}`)) {
+		t.Fatal("IsEnvelope = true for a plain session file")
+	}
+}
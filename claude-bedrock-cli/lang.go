@@ -0,0 +1,100 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+)
+
+// languageNames maps the ISO 639-1 codes detectLanguage can return to the
+// English names used in prompts.
+var languageNames = map[string]string{
+	"en": "English",
+	"es": "Spanish",
+	"fr": "French",
+	"de": "German",
+	"it": "Italian",
+	"pt": "Portuguese",
+	"nl": "Dutch",
+	"ru": "Russian",
+	"ar": "Arabic",
+	"ja": "Japanese",
+	"ko": "Korean",
+	"zh": "Chinese",
+}
+
+// stopwords are high-frequency function words that separate the supported
+// Latin-script languages from each other.
+var stopwords = map[string][]string{
+	"en": {"the", "and", "of", "to", "is", "that", "with", "for"},
+	"es": {"el", "la", "los", "las", "que", "de", "y", "para"},
+	"fr": {"le", "la", "les", "des", "est", "que", "et", "dans"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "mit", "für"},
+	"it": {"il", "la", "che", "di", "è", "per", "con", "non"},
+	"pt": {"o", "a", "os", "as", "que", "de", "é", "para"},
+	"nl": {"de", "het", "een", "en", "van", "is", "niet", "voor"},
+}
+
+// detectLanguage guesses the dominant language of text, returning an ISO
+// 639-1 code or "" when unsure. Script ranges identify non-Latin languages;
+// Latin-script languages are separated by stopword frequency. It is
+// deliberately lightweight — enough to catch a response in the wrong
+// language, not a general-purpose classifier.
+func detectLanguage(text string) string {
+	var han, kana, hangul, cyrillic, arabic, latin int
+	for _, r := range text {
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			kana++
+		case unicode.In(r, unicode.Han):
+			han++
+		case unicode.In(r, unicode.Hangul):
+			hangul++
+		case unicode.In(r, unicode.Cyrillic):
+			cyrillic++
+		case unicode.In(r, unicode.Arabic):
+			arabic++
+		case unicode.In(r, unicode.Latin):
+			latin++
+		}
+	}
+	total := han + kana + hangul + cyrillic + arabic + latin
+	if total == 0 {
+		return ""
+	}
+	switch {
+	case kana*5 > total:
+		return "ja"
+	case hangul*2 > total:
+		return "ko"
+	case han*2 > total:
+		return "zh"
+	case cyrillic*2 > total:
+		return "ru"
+	case arabic*2 > total:
+		return "ar"
+	}
+
+	words := strings.Fields(strings.ToLower(text))
+	if len(words) == 0 {
+		return ""
+	}
+	wordSet := make(map[string]int, len(words))
+	for _, w := range words {
+		wordSet[strings.Trim(w, ".,;:!?()\"'")]++
+	}
+	best, bestHits := "", 0
+	for code, markers := range stopwords {
+		hits := 0
+		for _, marker := range markers {
+			hits += wordSet[marker]
+		}
+		if hits > bestHits {
+			best, bestHits = code, hits
+		}
+	}
+	// Require a minimal signal before claiming a language.
+	if bestHits*50 < len(words) {
+		return ""
+	}
+	return best
+}
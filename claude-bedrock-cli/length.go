@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+)
+
+// lengthConstraint is a hard cap on output size, for fixed-size summary
+// slots and similar layouts. Zero fields are unconstrained.
+type lengthConstraint struct {
+	Words     int
+	Sentences int
+}
+
+func (lc lengthConstraint) active() bool {
+	return lc.Words > 0 || lc.Sentences > 0
+}
+
+// instruction phrases the constraint for the model.
+func (lc lengthConstraint) instruction() string {
+	var parts []string
+	if lc.Words > 0 {
+		parts = append(parts, fmt.Sprintf("at most %d words", lc.Words))
+	}
+	if lc.Sentences > 0 {
+		parts = append(parts, fmt.Sprintf("at most %d sentences", lc.Sentences))
+	}
+	return "Your response must be " + strings.Join(parts, " and ") + "."
+}
+
+// exceeded measures the text against the constraint.
+func (lc lengthConstraint) exceeded(text string) bool {
+	if lc.Words > 0 && wordCount(text) > lc.Words {
+		return true
+	}
+	if lc.Sentences > 0 && sentenceCount(text) > lc.Sentences {
+		return true
+	}
+	return false
+}
+
+// enforce re-prompts once for a shorter version when the answer overruns the
+// constraint, mirroring the summarize command's word-target handling.
+func (lc lengthConstraint) enforce(ctx context.Context, client *claude.Client, req claude.Request, text string, usage *claude.Usage) (string, error) {
+	if !lc.active() || !lc.exceeded(text) {
+		return text, nil
+	}
+	retry := req
+	retry.Messages = append(append([]claude.Message(nil), req.Messages...),
+		claude.AssistantMessage(text),
+		claude.UserMessage(fmt.Sprintf(
+			"That response is %d words and %d sentences, over the limit. Rewrite it: %s Keep the most important content.",
+			wordCount(text), sentenceCount(text), lc.instruction())),
+	)
+	shorter, u, err := client.ConverseUsage(ctx, retry)
+	if err != nil {
+		return text, err
+	}
+	usage.Add(u)
+	return shorter, nil
+}
+
+// sentenceCount approximates sentences by terminal punctuation.
+func sentenceCount(s string) int {
+	count := 0
+	for _, field := range strings.Fields(s) {
+		switch field[len(field)-1] {
+		case '.', '!', '?':
+			count++
+		}
+	}
+	return count
+}
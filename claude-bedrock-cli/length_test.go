@@ -0,0 +1,52 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLengthConstraintActive(t *testing.T) {
+	if (lengthConstraint{}).active() {
+		t.Error("zero constraint reports active")
+	}
+	if !(lengthConstraint{Words: 10}).active() || !(lengthConstraint{Sentences: 2}).active() {
+		t.Error("non-zero constraint reports inactive")
+	}
+}
+
+func TestLengthConstraintInstruction(t *testing.T) {
+	got := lengthConstraint{Words: 50, Sentences: 3}.instruction()
+	if !strings.Contains(got, "at most 50 words") || !strings.Contains(got, "at most 3 sentences") {
+		t.Errorf("instruction missing a limit: %q", got)
+	}
+}
+
+func TestLengthConstraintExceeded(t *testing.T) {
+	lc := lengthConstraint{Words: 5, Sentences: 1}
+	if lc.exceeded("short enough.") {
+		t.Error("compliant text reported as exceeded")
+	}
+	if !lc.exceeded("this answer has rather too many words in it.") {
+		t.Error("over-word text not reported")
+	}
+	if !lc.exceeded("One. Two.") {
+		t.Error("over-sentence text not reported")
+	}
+}
+
+func TestSentenceCount(t *testing.T) {
+	cases := []struct {
+		text string
+		want int
+	}{
+		{"", 0},
+		{"No terminal punctuation", 0},
+		{"One. Two! Three?", 3},
+		{"Trailing words after. the end", 1},
+	}
+	for _, c := range cases {
+		if got := sentenceCount(c.text); got != c.want {
+			t.Errorf("sentenceCount(%q) = %d, want %d", c.text, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// claude-bedrock-cli is a command-line playground for Anthropic Claude models
+// on Amazon Bedrock.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+)
+
+func usage() {
+	fmt.Fprint(os.Stderr, "Usage: claude-bedrock-cli [-quiet] <command> [options]\n\nCommands:\n")
+	for _, cmd := range commands {
+		if cmd.hidden {
+			continue
+		}
+		if len(cmd.name) > 11 {
+			fmt.Fprintf(os.Stderr, "  %s\n              %s\n", cmd.name, cmd.summary)
+		} else {
+			fmt.Fprintf(os.Stderr, "  %-12s%s\n", cmd.name, cmd.summary)
+		}
+	}
+	fmt.Fprint(os.Stderr, `
+Global options:
+  -quiet      Suppress decorative output (spinners, progress, stderr notes);
+              only the answer goes to stdout, diagnostics to stderr.
+              Also enabled by CLAUDE_CLI_QUIET=1.
+
+Run "claude-bedrock-cli <command> -h" for command options.
+`)
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("claude-bedrock-cli: ")
+	args := os.Args[1:]
+	quietMode = os.Getenv("CLAUDE_CLI_QUIET") == "1"
+	for len(args) > 0 && (args[0] == "-quiet" || args[0] == "--quiet") {
+		quietMode = true
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+	switch args[0] {
+	case "help", "-h", "--help":
+		usage()
+		return
+	}
+	cmd := lookupCommand(args[0])
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", args[0])
+		usage()
+		os.Exit(2)
+	}
+	if err := cmd.run(ctx, args[1:]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// readInput returns the contents of path, or stdin when path is "-" or
+// empty, decoded to UTF-8 with LF line endings regardless of the source
+// encoding.
+func readInput(path string) (string, error) {
+	if path == "" || path == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return "", fmt.Errorf("reading stdin: %w", err)
+		}
+		return decodeText(data), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return decodeText(data), nil
+}
@@ -0,0 +1,114 @@
+// Package pack fits prioritized context pieces into a model's input
+// window: highest-priority pieces are kept whole, low-priority ones can
+// be compressed to summaries, and whatever still does not fit is dropped
+// and reported.
+package pack
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+)
+
+// Piece is one candidate for inclusion in the context window.
+type Piece struct {
+	ID   string
+	Text string
+	// Priority orders packing: higher goes in first. Ties keep input
+	// order.
+	Priority float64
+}
+
+// Result reports what a packing run kept, shrank, and discarded. Included
+// pieces are in their original input order, not priority order, so the
+// assembled context reads coherently.
+type Result struct {
+	Included   []Piece
+	Compressed []string
+	Dropped    []Piece
+	Chars      int
+}
+
+// Packer fits pieces into a character budget.
+type Packer struct {
+	// Budget is the character allowance for all included pieces.
+	Budget int
+	// Compress, when non-nil, shrinks a piece that would not fit whole
+	// (e.g. by summarization); returning "" drops the piece instead.
+	Compress func(ctx context.Context, p Piece) (string, error)
+	// CompressBelow limits Compress to pieces with Priority strictly
+	// below it; pieces at or above the threshold are never summarized,
+	// only kept whole or dropped.
+	CompressBelow float64
+}
+
+// Pack greedily admits pieces in priority order until the budget is
+// spent.
+func (pk *Packer) Pack(ctx context.Context, pieces []Piece) (Result, error) {
+	if pk.Budget <= 0 {
+		return Result{}, fmt.Errorf("pack: budget must be positive")
+	}
+	order := make([]int, len(pieces))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return pieces[order[a]].Priority > pieces[order[b]].Priority
+	})
+
+	included := make(map[int]Piece)
+	var result Result
+	remaining := pk.Budget
+	for _, idx := range order {
+		p := pieces[idx]
+		if len(p.Text) <= remaining {
+			included[idx] = p
+			remaining -= len(p.Text)
+			continue
+		}
+		if pk.Compress != nil && p.Priority < pk.CompressBelow {
+			short, err := pk.Compress(ctx, p)
+			if err != nil {
+				return Result{}, fmt.Errorf("pack: compressing %s: %w", p.ID, err)
+			}
+			if short != "" && len(short) <= remaining {
+				compressed := p
+				compressed.Text = short
+				included[idx] = compressed
+				remaining -= len(short)
+				result.Compressed = append(result.Compressed, p.ID)
+				continue
+			}
+		}
+		result.Dropped = append(result.Dropped, p)
+	}
+
+	for idx := range pieces {
+		if p, ok := included[idx]; ok {
+			result.Included = append(result.Included, p)
+			result.Chars += len(p.Text)
+		}
+	}
+	return result, nil
+}
+
+// Summarizer returns a Compress hook that condenses a piece with a model
+// call, targeting roughly maxChars of output.
+func Summarizer(client *claude.Client, maxChars int) func(ctx context.Context, p Piece) (string, error) {
+	return func(ctx context.Context, p Piece) (string, error) {
+		out, err := client.Converse(ctx, claude.Request{
+			System: fmt.Sprintf("Condense the text to its essential facts in at most %d characters. Output only the condensed text.", maxChars),
+			Messages: []claude.Message{
+				claude.UserMessage(p.Text),
+			},
+			MaxTokens: int32(maxChars/3 + 64),
+		})
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(out), nil
+	}
+}
@@ -0,0 +1,130 @@
+package pack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestPackKeepsInputOrder(t *testing.T) {
+	pieces := []Piece{
+		{ID: "a", Text: "aaaa", Priority: 1},
+		{ID: "b", Text: "bbbb", Priority: 3},
+		{ID: "c", Text: "cccc", Priority: 2},
+	}
+	result, err := (&Packer{Budget: 100}).Pack(context.Background(), pieces)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Included) != 3 {
+		t.Fatalf("included %d pieces, want 3", len(result.Included))
+	}
+	for i, want := range []string{"a", "b", "c"} {
+		if result.Included[i].ID != want {
+			t.Errorf("included[%d] = %s, want %s (input order, not priority order)", i, result.Included[i].ID, want)
+		}
+	}
+	if result.Chars != 12 {
+		t.Errorf("Chars = %d, want 12", result.Chars)
+	}
+}
+
+func TestPackDropsLowPriorityFirst(t *testing.T) {
+	pieces := []Piece{
+		{ID: "low", Text: strings.Repeat("x", 60), Priority: 1},
+		{ID: "high", Text: strings.Repeat("y", 60), Priority: 2},
+	}
+	result, err := (&Packer{Budget: 80}).Pack(context.Background(), pieces)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Included) != 1 || result.Included[0].ID != "high" {
+		t.Fatalf("Included = %v, want just high", result.Included)
+	}
+	if len(result.Dropped) != 1 || result.Dropped[0].ID != "low" {
+		t.Fatalf("Dropped = %v, want just low", result.Dropped)
+	}
+}
+
+func TestPackCompressesBelowThreshold(t *testing.T) {
+	pieces := []Piece{
+		{ID: "keep", Text: strings.Repeat("k", 50), Priority: 5},
+		{ID: "shrink", Text: strings.Repeat("s", 50), Priority: 1},
+	}
+	pk := &Packer{
+		Budget:        70,
+		CompressBelow: 2,
+		Compress: func(ctx context.Context, p Piece) (string, error) {
+			return "short " + p.ID, nil
+		},
+	}
+	result, err := pk.Pack(context.Background(), pieces)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Included) != 2 {
+		t.Fatalf("included %d pieces, want both", len(result.Included))
+	}
+	if result.Included[1].Text != "short shrink" {
+		t.Errorf("low-priority piece was not compressed: %q", result.Included[1].Text)
+	}
+	if len(result.Compressed) != 1 || result.Compressed[0] != "shrink" {
+		t.Errorf("Compressed = %v, want [shrink]", result.Compressed)
+	}
+}
+
+func TestPackNeverCompressesAboveThreshold(t *testing.T) {
+	pieces := []Piece{
+		{ID: "important", Text: strings.Repeat("i", 100), Priority: 9},
+	}
+	pk := &Packer{
+		Budget:        50,
+		CompressBelow: 2,
+		Compress: func(ctx context.Context, p Piece) (string, error) {
+			t.Error("Compress called for a piece at or above CompressBelow")
+			return "", nil
+		},
+	}
+	result, err := pk.Pack(context.Background(), pieces)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Dropped) != 1 {
+		t.Fatalf("Dropped = %v, want the oversized piece", result.Dropped)
+	}
+}
+
+func TestPackCompressReturningEmptyDrops(t *testing.T) {
+	pieces := []Piece{{ID: "x", Text: strings.Repeat("x", 100), Priority: 1}}
+	pk := &Packer{
+		Budget:        50,
+		CompressBelow: 2,
+		Compress:      func(ctx context.Context, p Piece) (string, error) { return "", nil },
+	}
+	result, err := pk.Pack(context.Background(), pieces)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Included) != 0 || len(result.Dropped) != 1 {
+		t.Fatalf("Included=%v Dropped=%v, want the piece dropped", result.Included, result.Dropped)
+	}
+}
+
+func TestPackCompressErrorFails(t *testing.T) {
+	pieces := []Piece{{ID: "x", Text: strings.Repeat("x", 100), Priority: 1}}
+	pk := &Packer{
+		Budget:        50,
+		CompressBelow: 2,
+		Compress:      func(ctx context.Context, p Piece) (string, error) { return "", fmt.Errorf("boom") },
+	}
+	if _, err := pk.Pack(context.Background(), pieces); err == nil {
+		t.Fatal("Pack swallowed the Compress error")
+	}
+}
+
+func TestPackRejectsZeroBudget(t *testing.T) {
+	if _, err := (&Packer{}).Pack(context.Background(), nil); err == nil {
+		t.Fatal("Pack accepted a zero budget")
+	}
+}
@@ -0,0 +1,151 @@
+// Package patch parses and applies unified diffs, the format the model is
+// asked to emit when proposing code changes.
+package patch
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FileDiff is the change set for one file.
+type FileDiff struct {
+	Path  string
+	Hunks []Hunk
+}
+
+// Hunk is one @@-delimited change block.
+type Hunk struct {
+	Header string   // the @@ line as written
+	Lines  []string // body lines, each prefixed with ' ', '+', or '-'
+}
+
+// Parse reads a unified diff into per-file hunks. It tolerates the
+// surrounding prose and code fences models tend to add around diffs.
+func Parse(text string) ([]FileDiff, error) {
+	var diffs []FileDiff
+	var current *FileDiff
+	var hunk *Hunk
+
+	flushHunk := func() {
+		if hunk != nil && current != nil {
+			current.Hunks = append(current.Hunks, *hunk)
+		}
+		hunk = nil
+	}
+	flushFile := func() {
+		flushHunk()
+		if current != nil && len(current.Hunks) > 0 {
+			diffs = append(diffs, *current)
+		}
+		current = nil
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		switch {
+		case strings.HasPrefix(line, "--- "):
+			flushFile()
+		case strings.HasPrefix(line, "+++ "):
+			path := strings.TrimSpace(strings.TrimPrefix(line, "+++ "))
+			path = strings.TrimPrefix(path, "b/")
+			if i := strings.IndexByte(path, '\t'); i >= 0 {
+				path = path[:i]
+			}
+			current = &FileDiff{Path: path}
+		case strings.HasPrefix(line, "@@"):
+			if current == nil {
+				return nil, fmt.Errorf("patch: hunk before file header: %s", line)
+			}
+			flushHunk()
+			hunk = &Hunk{Header: line}
+		case hunk != nil && (line == "" || strings.IndexByte(" +-\\", line[0]) >= 0):
+			if line == "" {
+				line = " "
+			}
+			if line[0] == '\\' { // "\ No newline at end of file"
+				continue
+			}
+			hunk.Lines = append(hunk.Lines, line)
+		default:
+			// Prose between diffs ends the current hunk.
+			flushHunk()
+		}
+	}
+	flushFile()
+	if len(diffs) == 0 {
+		return nil, fmt.Errorf("patch: no unified diff found")
+	}
+	return diffs, nil
+}
+
+// old and new return the pre- and post-image lines of a hunk.
+func (h Hunk) old() []string {
+	var lines []string
+	for _, l := range h.Lines {
+		if l[0] == ' ' || l[0] == '-' {
+			lines = append(lines, l[1:])
+		}
+	}
+	return lines
+}
+
+func (h Hunk) new() []string {
+	var lines []string
+	for _, l := range h.Lines {
+		if l[0] == ' ' || l[0] == '+' {
+			lines = append(lines, l[1:])
+		}
+	}
+	return lines
+}
+
+// String renders the hunk for display.
+func (h Hunk) String() string {
+	return h.Header + "\n" + strings.Join(h.Lines, "\n")
+}
+
+// ApplyHunk applies one hunk to content by locating its pre-image. The match
+// must be unique; ambiguous or missing context is an error rather than a
+// guess.
+func ApplyHunk(content string, h Hunk) (string, error) {
+	lines := strings.Split(content, "\n")
+	oldLines := h.old()
+	newLines := h.new()
+
+	if len(oldLines) == 0 { // pure addition into an empty file
+		if strings.TrimSpace(content) != "" {
+			return "", fmt.Errorf("patch: hunk has no context for non-empty file")
+		}
+		return strings.Join(newLines, "\n"), nil
+	}
+
+	matches := 0
+	at := -1
+	for i := 0; i+len(oldLines) <= len(lines); i++ {
+		if equalSlice(lines[i:i+len(oldLines)], oldLines) {
+			matches++
+			at = i
+		}
+	}
+	switch matches {
+	case 0:
+		return "", fmt.Errorf("patch: hunk context not found (%s)", h.Header)
+	case 1:
+	default:
+		return "", fmt.Errorf("patch: hunk context is ambiguous (%d matches for %s)", matches, h.Header)
+	}
+
+	updated := append(lines[:at:at], append(newLines, lines[at+len(oldLines):]...)...)
+	return strings.Join(updated, "\n"), nil
+}
+
+func equalSlice(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
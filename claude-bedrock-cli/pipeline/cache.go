@@ -0,0 +1,58 @@
+package pipeline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Cache persists per-stage outputs keyed by a hash of everything that
+// feeds the stage: model, system prompt, and the prompt after placeholder
+// substitution. Re-running a pipeline after a failure or a prompt tweak
+// therefore only re-executes the stages whose inputs actually changed;
+// edits upstream change the substituted prompt and invalidate dependents
+// automatically.
+type Cache struct {
+	dir string
+}
+
+// OpenCache creates (if needed) and returns the on-disk stage cache. When
+// dir is empty the user cache directory is used.
+func OpenCache(dir string) (*Cache, error) {
+	if dir == "" {
+		base, err := os.UserCacheDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Join(base, "claude-bedrock-cli", "pipelines")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Cache{dir: dir}, nil
+}
+
+// key hashes the fully resolved stage inputs.
+func (c *Cache) key(model, system, prompt string, maxTokens int32) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00%d", model, system, prompt, maxTokens)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// get returns the cached output for the resolved stage, or ok=false on a
+// miss.
+func (c *Cache) get(model, system, prompt string, maxTokens int32) (output string, ok bool) {
+	data, err := os.ReadFile(filepath.Join(c.dir, c.key(model, system, prompt, maxTokens)))
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// put stores the output for the resolved stage. Write failures are
+// returned so callers can warn without failing the run.
+func (c *Cache) put(model, system, prompt string, maxTokens int32, output string) error {
+	return os.WriteFile(filepath.Join(c.dir, c.key(model, system, prompt, maxTokens)), []byte(output), 0o644)
+}
@@ -0,0 +1,199 @@
+// Package pipeline executes named multi-stage prompt chains defined in
+// YAML: each stage has its own model, prompt template, and inputs mapped
+// from earlier stages' outputs.
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+)
+
+// Stage is one prompt in the chain. Its prompt template may reference
+// {{input}} (the pipeline input) and {{<stage>}} for any earlier stage's
+// output.
+//
+// A stage with Inputs set is a fan-in aggregation: the named upstream
+// outputs are wrapped in <input name="..."> blocks and substituted for
+// {{inputs}} in the prompt. When the combined inputs would not fit in the
+// context window, they are aggregated in batches first and the prompt is
+// run once more over the batch results.
+type Stage struct {
+	Name      string   `yaml:"name"`
+	Model     string   `yaml:"model,omitempty"`
+	System    string   `yaml:"system,omitempty"`
+	Prompt    string   `yaml:"prompt"`
+	MaxTokens int32    `yaml:"max_tokens,omitempty"`
+	Inputs    []string `yaml:"inputs,omitempty"`
+}
+
+// Pipeline is a named chain of stages executed in order. Cache, when
+// non-nil, skips stages whose resolved inputs have an output on disk from
+// an earlier run.
+type Pipeline struct {
+	Name   string  `yaml:"name"`
+	Stages []Stage `yaml:"stages"`
+	Cache  *Cache  `yaml:"-"`
+}
+
+var placeholder = regexp.MustCompile(`\{\{\s*([a-zA-Z0-9_-]+)\s*\}\}`)
+
+// Load reads and validates a pipeline definition.
+func Load(path string) (*Pipeline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var p Pipeline
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("pipeline %s: %w", path, err)
+	}
+	if len(p.Stages) == 0 {
+		return nil, fmt.Errorf("pipeline %s: no stages defined", path)
+	}
+	seen := map[string]bool{"input": true}
+	for i, stage := range p.Stages {
+		if stage.Name == "" {
+			return nil, fmt.Errorf("pipeline %s: stage %d needs a name", path, i+1)
+		}
+		if seen[stage.Name] {
+			return nil, fmt.Errorf("pipeline %s: duplicate stage name %q", path, stage.Name)
+		}
+		if stage.Prompt == "" {
+			return nil, fmt.Errorf("pipeline %s: stage %s needs a prompt", path, stage.Name)
+		}
+		for _, in := range stage.Inputs {
+			if !seen[in] {
+				return nil, fmt.Errorf("pipeline %s: stage %s aggregates unknown stage %q", path, stage.Name, in)
+			}
+		}
+		if len(stage.Inputs) > 0 && !strings.Contains(stage.Prompt, "{{inputs}}") {
+			return nil, fmt.Errorf("pipeline %s: aggregation stage %s must reference {{inputs}} in its prompt", path, stage.Name)
+		}
+		for _, m := range placeholder.FindAllStringSubmatch(stage.Prompt, -1) {
+			if m[1] == "inputs" && len(stage.Inputs) > 0 {
+				continue
+			}
+			if !seen[m[1]] {
+				return nil, fmt.Errorf("pipeline %s: stage %s references unknown input {{%s}}", path, stage.Name, m[1])
+			}
+		}
+		seen[stage.Name] = true
+	}
+	return &p, nil
+}
+
+// Run executes the stages in order and returns every stage's output keyed
+// by name; the last stage's output is the pipeline result. onStage, when
+// non-nil, observes each completed stage.
+func (p *Pipeline) Run(ctx context.Context, client *claude.Client, input string, onStage func(stage, output string)) (map[string]string, string, error) {
+	outputs := map[string]string{"input": input}
+	last := ""
+	for _, stage := range p.Stages {
+		stageClient := client
+		if stage.Model != "" {
+			stageClient = client.WithModel(stage.Model)
+		}
+		maxTokens := stage.MaxTokens
+		if maxTokens <= 0 {
+			maxTokens = 4096
+		}
+		var out string
+		var err error
+		if len(stage.Inputs) > 0 {
+			out, err = p.aggregate(ctx, stageClient, stage, maxTokens, outputs)
+		} else {
+			out, err = p.converse(ctx, stageClient, stage, resolve(stage.Prompt, outputs, ""), maxTokens)
+		}
+		if err != nil {
+			return outputs, "", fmt.Errorf("pipeline %s: stage %s: %w", p.Name, stage.Name, err)
+		}
+		outputs[stage.Name] = out
+		last = out
+		if onStage != nil {
+			onStage(stage.Name, out)
+		}
+	}
+	return outputs, last, nil
+}
+
+// resolve substitutes stage placeholders in tmpl; in aggregation stages
+// inputs replaces {{inputs}}.
+func resolve(tmpl string, outputs map[string]string, inputs string) string {
+	return placeholder.ReplaceAllStringFunc(tmpl, func(m string) string {
+		name := placeholder.FindStringSubmatch(m)[1]
+		if name == "inputs" && inputs != "" {
+			return inputs
+		}
+		return outputs[name]
+	})
+}
+
+// converse runs one model call for a stage, going through the stage cache
+// when one is attached.
+func (p *Pipeline) converse(ctx context.Context, client *claude.Client, stage Stage, prompt string, maxTokens int32) (string, error) {
+	if p.Cache != nil {
+		if out, ok := p.Cache.get(client.ModelID, stage.System, prompt, maxTokens); ok {
+			return out, nil
+		}
+	}
+	out, err := client.Converse(ctx, claude.Request{
+		System:    stage.System,
+		Messages:  []claude.Message{claude.UserMessage(prompt)},
+		MaxTokens: maxTokens,
+	})
+	if err != nil {
+		return "", err
+	}
+	out = strings.TrimSpace(out)
+	if p.Cache != nil {
+		if err := p.Cache.put(client.ModelID, stage.System, prompt, maxTokens, out); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: caching stage %s: %v\n", stage.Name, err)
+		}
+	}
+	return out, nil
+}
+
+// maxAggregateChars roughly bounds the text handed to one aggregation
+// call; at ~4 characters per token this stays well inside a 200k-token
+// context window with room for the prompt and the response.
+const maxAggregateChars = 400000
+
+// aggregate runs a fan-in stage. The named upstream outputs are combined
+// into one prompt when they fit; otherwise they are reduced in batches,
+// re-running the same prompt over the batch results until a single
+// aggregation call remains.
+func (p *Pipeline) aggregate(ctx context.Context, client *claude.Client, stage Stage, maxTokens int32, outputs map[string]string) (string, error) {
+	blocks := make([]string, 0, len(stage.Inputs))
+	for _, name := range stage.Inputs {
+		blocks = append(blocks, fmt.Sprintf("<input name=%q>\n%s\n</input>", name, outputs[name]))
+	}
+	for {
+		combined := strings.Join(blocks, "\n")
+		if len(combined) <= maxAggregateChars || len(blocks) == 1 {
+			return p.converse(ctx, client, stage, resolve(stage.Prompt, outputs, combined), maxTokens)
+		}
+		var next []string
+		for start := 0; start < len(blocks); {
+			size, end := 0, start
+			for end < len(blocks) && (end == start || size+len(blocks[end]) <= maxAggregateChars) {
+				size += len(blocks[end])
+				end++
+			}
+			batch := strings.Join(blocks[start:end], "\n")
+			out, err := p.converse(ctx, client, stage, resolve(stage.Prompt, outputs, batch), maxTokens)
+			if err != nil {
+				return "", err
+			}
+			next = append(next, fmt.Sprintf("<input name=\"batch-%d\">\n%s\n</input>", len(next)+1, out))
+			start = end
+		}
+		blocks = next
+	}
+}
@@ -0,0 +1,30 @@
+package postprocess
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ExtractTag returns the contents of the first <tag>...</tag> block,
+// trimmed. When the tag is absent the input is returned unchanged (minus
+// preamble), so a model that ignored the tagging instruction still yields
+// usable output.
+func ExtractTag(text, tag string) string {
+	re, err := regexp.Compile(`(?s)<` + regexp.QuoteMeta(tag) + `(?:\s[^>]*)?>(.*?)</` + regexp.QuoteMeta(tag) + `>`)
+	if err != nil {
+		return text
+	}
+	if m := re.FindStringSubmatch(text); m != nil {
+		return strings.TrimSpace(m[1])
+	}
+	return strings.TrimSpace(StripPreamble(text))
+}
+
+// AfterMarker returns everything after the first occurrence of marker,
+// trimmed, or the input unchanged when the marker is absent.
+func AfterMarker(text, marker string) string {
+	if _, after, found := strings.Cut(text, marker); found {
+		return strings.TrimSpace(after)
+	}
+	return text
+}
@@ -0,0 +1,110 @@
+// Package postprocess cleans up assembled model output before it reaches
+// the user or a downstream pipeline, via small named stages that compose in
+// order.
+package postprocess
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Stage is one output transformation.
+type Stage struct {
+	Name  string
+	Apply func(text string) string
+}
+
+// stages indexes the built-in transformations by config name.
+var stages = map[string]func(string) string{
+	"strip-markdown":      StripMarkdown,
+	"collapse-whitespace": CollapseWhitespace,
+	"strip-preamble":      StripPreamble,
+	"mask-profanity":      MaskProfanity,
+}
+
+// Names lists the available stage names.
+func Names() []string {
+	return []string{"strip-markdown", "collapse-whitespace", "strip-preamble", "mask-profanity"}
+}
+
+// Pipeline resolves a comma-separated stage list into executable stages,
+// applied in the order given.
+func Pipeline(spec string) ([]Stage, error) {
+	var pipeline []Stage
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		apply, ok := stages[name]
+		if !ok {
+			return nil, fmt.Errorf("postprocess: unknown stage %q (available: %s)", name, strings.Join(Names(), ", "))
+		}
+		pipeline = append(pipeline, Stage{Name: name, Apply: apply})
+	}
+	return pipeline, nil
+}
+
+// Run applies the stages in order.
+func Run(pipeline []Stage, text string) string {
+	for _, stage := range pipeline {
+		text = stage.Apply(text)
+	}
+	return text
+}
+
+var (
+	markdownHeading  = regexp.MustCompile(`(?m)^#{1,6}\s+`)
+	markdownEmphasis = regexp.MustCompile(`(\*\*|__|\*|_)(\S(?:.*?\S)?)(\*\*|__|\*|_)`)
+	markdownFence    = regexp.MustCompile("(?m)^```[a-zA-Z0-9]*$\n?")
+	markdownLink     = regexp.MustCompile(`\[([^\]]+)\]\([^)]+\)`)
+)
+
+// StripMarkdown removes common markdown markup, leaving plain text.
+func StripMarkdown(text string) string {
+	text = markdownFence.ReplaceAllString(text, "")
+	text = markdownHeading.ReplaceAllString(text, "")
+	text = markdownLink.ReplaceAllString(text, "$1")
+	text = markdownEmphasis.ReplaceAllString(text, "$2")
+	return text
+}
+
+var multiBlank = regexp.MustCompile(`\n{3,}`)
+var trailingSpace = regexp.MustCompile(`(?m)[ \t]+$`)
+
+// CollapseWhitespace trims trailing spaces and squeezes runs of blank lines.
+func CollapseWhitespace(text string) string {
+	text = trailingSpace.ReplaceAllString(text, "")
+	text = multiBlank.ReplaceAllString(text, "\n\n")
+	return strings.TrimSpace(text) + "\n"
+}
+
+// preambleLine matches conversational openers models prepend to answers.
+var preambleLine = regexp.MustCompile(`(?i)^(sure|certainly|of course|here('s| is| are)\b|below (is|are)\b|i('ve| have)\b|as requested\b|the following\b).*[:.!]\s*$`)
+
+// StripPreamble drops leading boilerplate lines like "Here is the summary:".
+func StripPreamble(text string) string {
+	lines := strings.Split(text, "\n")
+	for len(lines) > 0 {
+		first := strings.TrimSpace(lines[0])
+		if first == "" || preambleLine.MatchString(first) {
+			lines = lines[1:]
+			continue
+		}
+		break
+	}
+	return strings.Join(lines, "\n")
+}
+
+// profanity is intentionally short: the mask is a formatting nicety, not a
+// content filter — use guardrails for real moderation.
+var profanity = regexp.MustCompile(`(?i)\b(fuck\w*|shit\w*|asshole\w*|bitch\w*|bastard\w*|damn\w*)\b`)
+
+// MaskProfanity replaces profane words with their first letter and
+// asterisks.
+func MaskProfanity(text string) string {
+	return profanity.ReplaceAllStringFunc(text, func(word string) string {
+		return word[:1] + strings.Repeat("*", len(word)-1)
+	})
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// quietMode suppresses all decorative output — spinners, progress bars, and
+// informational stderr notes — leaving only the answer on stdout and real
+// diagnostics on stderr, so the tool embeds cleanly in shell pipelines and
+// Makefiles. It is set by the global -quiet flag or CLAUDE_CLI_QUIET=1.
+var quietMode bool
+
+// note prints an informational stderr line, unless quiet mode is on.
+func note(format string, args ...any) {
+	if quietMode {
+		return
+	}
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+}
+
+// stderrIsTTY reports whether stderr is an interactive terminal. Progress
+// decorations are suppressed when it is not (or in quiet mode), so pipelines
+// and CI logs stay clean.
+func stderrIsTTY() bool {
+	if quietMode {
+		return false
+	}
+	info, err := os.Stderr.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// clearLine erases the current stderr line, removing a spinner or bar.
+const clearLine = "\r\x1b[K"
+
+var spinnerFrames = []string{"|", "/", "-", "\\"}
+
+// spinner shows elapsed time (and, once reported, time to first token) on
+// stderr while a request is in flight. Every method is a no-op when stderr
+// is not a TTY.
+type spinner struct {
+	label string
+	start time.Time
+
+	mu    sync.Mutex
+	first time.Duration
+	done  chan struct{}
+}
+
+// startSpinner begins animating on stderr until Stop is called.
+func startSpinner(label string) *spinner {
+	sp := &spinner{label: label, start: time.Now()}
+	if !stderrIsTTY() {
+		return sp
+	}
+	sp.done = make(chan struct{})
+	go func(done chan struct{}) {
+		tick := time.NewTicker(120 * time.Millisecond)
+		defer tick.Stop()
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			case <-tick.C:
+				sp.mu.Lock()
+				first := sp.first
+				sp.mu.Unlock()
+				line := fmt.Sprintf("\r%s %s %.1fs", spinnerFrames[i%len(spinnerFrames)], sp.label, time.Since(sp.start).Seconds())
+				if first > 0 {
+					line += fmt.Sprintf(" (first token %.1fs)", first.Seconds())
+				}
+				fmt.Fprint(os.Stderr, line+"\x1b[K")
+			}
+		}
+	}(sp.done)
+	return sp
+}
+
+// FirstToken records the time to first token; later calls are ignored.
+func (sp *spinner) FirstToken() {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+	if sp.first == 0 {
+		sp.first = time.Since(sp.start)
+	}
+}
+
+// Stop halts the animation and clears its line. Safe to call repeatedly.
+func (sp *spinner) Stop() {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+	if sp.done == nil {
+		return
+	}
+	close(sp.done)
+	sp.done = nil
+	fmt.Fprint(os.Stderr, clearLine)
+}
+
+// firstTokenWriter reports the first write to a spinner, so the first-token
+// timer shows while a response streams somewhere other than the terminal.
+type firstTokenWriter struct {
+	w  io.Writer
+	sp *spinner
+}
+
+func (fw firstTokenWriter) Write(p []byte) (int, error) {
+	fw.sp.FirstToken()
+	return fw.w.Write(p)
+}
+
+// progressBar reports batch progress on stderr: a bar, the item currently
+// being worked on, the retry count, and an ETA extrapolated from completed
+// items. Every method is a no-op when stderr is not a TTY.
+type progressBar struct {
+	total int
+	start time.Time
+	tty   bool
+
+	mu      sync.Mutex
+	done    int
+	retries int
+	current string
+}
+
+// newProgressBar sizes a bar for total items.
+func newProgressBar(total int) *progressBar {
+	return &progressBar{total: total, start: time.Now(), tty: stderrIsTTY()}
+}
+
+// Start notes the item now being processed.
+func (pb *progressBar) Start(name string) {
+	pb.mu.Lock()
+	defer pb.mu.Unlock()
+	pb.current = name
+	pb.render()
+}
+
+// Retry counts one retried item.
+func (pb *progressBar) Retry() {
+	pb.mu.Lock()
+	defer pb.mu.Unlock()
+	pb.retries++
+	pb.render()
+}
+
+// Done marks the current item complete.
+func (pb *progressBar) Done() {
+	pb.mu.Lock()
+	defer pb.mu.Unlock()
+	pb.done++
+	pb.render()
+}
+
+// Close clears the bar's line once the batch finishes.
+func (pb *progressBar) Close() {
+	if pb.tty {
+		fmt.Fprint(os.Stderr, clearLine)
+	}
+}
+
+const progressBarWidth = 20
+
+func (pb *progressBar) render() {
+	if !pb.tty {
+		return
+	}
+	filled := 0
+	if pb.total > 0 {
+		filled = progressBarWidth * pb.done / pb.total
+	}
+	bar := strings.Repeat("=", filled) + strings.Repeat("-", progressBarWidth-filled)
+	line := fmt.Sprintf("\r[%s] %d/%d %s", bar, pb.done, pb.total, pb.current)
+	if pb.retries > 0 {
+		line += fmt.Sprintf(" (%d retries)", pb.retries)
+	}
+	if pb.done > 0 && pb.done < pb.total {
+		eta := time.Since(pb.start) / time.Duration(pb.done) * time.Duration(pb.total-pb.done)
+		line += " ETA " + eta.Round(time.Second).String()
+	}
+	fmt.Fprint(os.Stderr, line+"\x1b[K")
+}
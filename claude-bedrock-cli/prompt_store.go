@@ -0,0 +1,181 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// promptEntry is one saved prompt in the library.
+type promptEntry struct {
+	Name string   `json:"name"`
+	Text string   `json:"text"`
+	Tags []string `json:"tags,omitempty"`
+	// Variants maps a model matcher (a full model ID or a substring such
+	// as "haiku") to prompt text tuned for that model family; Text is the
+	// default for models without a variant.
+	Variants map[string]string `json:"variants,omitempty"`
+	Created  time.Time         `json:"created"`
+	Uses     int               `json:"uses,omitempty"`
+}
+
+// textFor picks the variant for the resolved model: an exact model-ID key
+// wins, then the longest key contained case-insensitively in the ID, then
+// the default text.
+func (e promptEntry) textFor(modelID string) string {
+	if text, ok := e.Variants[modelID]; ok {
+		return text
+	}
+	best := ""
+	lower := strings.ToLower(modelID)
+	for key := range e.Variants {
+		if key != "" && strings.Contains(lower, strings.ToLower(key)) && len(key) > len(best) {
+			best = key
+		}
+	}
+	if best != "" {
+		return e.Variants[best]
+	}
+	return e.Text
+}
+
+// promptStore persists the prompt library as a single JSON file.
+type promptStore struct {
+	path    string
+	Entries []promptEntry `json:"entries"`
+}
+
+// openPromptStore loads the library at path, defaulting to
+// ~/.claude-bedrock-cli/prompts.json beside the session store. A missing
+// file is an empty library.
+func openPromptStore(path string) (*promptStore, error) {
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(home, ".claude-bedrock-cli", "prompts.json")
+	}
+	store := &promptStore{path: path}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("corrupt prompt store %s: %w", path, err)
+	}
+	return store, nil
+}
+
+func (ps *promptStore) save() error {
+	if err := os.MkdirAll(filepath.Dir(ps.path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(ps, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(ps.path, data, 0o600)
+}
+
+// get returns the entry with the given name.
+func (ps *promptStore) get(name string) (promptEntry, bool) {
+	for _, e := range ps.Entries {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return promptEntry{}, false
+}
+
+// put inserts or replaces the entry by name.
+func (ps *promptStore) put(entry promptEntry) {
+	for i, e := range ps.Entries {
+		if e.Name == entry.Name {
+			ps.Entries[i] = entry
+			return
+		}
+	}
+	ps.Entries = append(ps.Entries, entry)
+}
+
+// delete removes the named entry, reporting whether it existed.
+func (ps *promptStore) delete(name string) bool {
+	for i, e := range ps.Entries {
+		if e.Name == name {
+			ps.Entries = append(ps.Entries[:i], ps.Entries[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// markUsed bumps the entry's use counter and persists it; recall ranking
+// favors frequently used prompts.
+func (ps *promptStore) markUsed(name string) {
+	for i := range ps.Entries {
+		if ps.Entries[i].Name == name {
+			ps.Entries[i].Uses++
+			break
+		}
+	}
+	ps.save()
+}
+
+// search returns entries matching the query, best first. The query is
+// matched as a case-insensitive subsequence of the name, then the tags,
+// then the prompt text, with name matches ranked above the others and
+// ties broken by use count.
+func (ps *promptStore) search(query string) []promptEntry {
+	type scored struct {
+		entry promptEntry
+		score int
+	}
+	var matches []scored
+	for _, e := range ps.Entries {
+		score := 0
+		switch {
+		case fuzzyMatches(query, e.Name):
+			score = 3
+		case fuzzyMatches(query, strings.Join(e.Tags, " ")):
+			score = 2
+		case fuzzyMatches(query, e.Text):
+			score = 1
+		default:
+			continue
+		}
+		matches = append(matches, scored{entry: e, score: score})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].entry.Uses > matches[j].entry.Uses
+	})
+	out := make([]promptEntry, len(matches))
+	for i, m := range matches {
+		out[i] = m.entry
+	}
+	return out
+}
+
+// fuzzyMatches reports whether query is a case-insensitive subsequence of
+// target, so "sumexec" matches "summarize-executive".
+func fuzzyMatches(query, target string) bool {
+	query = strings.ToLower(query)
+	target = strings.ToLower(target)
+	i := 0
+	for _, r := range target {
+		if i < len(query) && rune(query[i]) == r {
+			i++
+		}
+	}
+	return i == len(query)
+}
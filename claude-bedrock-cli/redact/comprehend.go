@@ -0,0 +1,77 @@
+package redact
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/comprehend"
+	"github.com/aws/aws-sdk-go-v2/service/comprehend/types"
+)
+
+// comprehendMaxBytes is the DetectPiiEntities document size limit; longer
+// texts are scanned in chunks.
+const comprehendMaxBytes = 90000
+
+// Comprehend detects PII with Amazon Comprehend and replaces each entity
+// with [REDACTED:<type>]. It catches what the built-in patterns cannot —
+// names, addresses, dates of birth — at the cost of an API call per text.
+type Comprehend struct {
+	client *comprehend.Client
+}
+
+// NewComprehend loads the default AWS configuration for the given region and
+// returns a Comprehend-backed redactor.
+func NewComprehend(ctx context.Context, region string) (*Comprehend, error) {
+	var opts []func(*awsconfig.LoadOptions) error
+	if region != "" {
+		opts = append(opts, awsconfig.WithRegion(region))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return &Comprehend{client: comprehend.NewFromConfig(cfg)}, nil
+}
+
+// Redact implements Redactor.
+func (c *Comprehend) Redact(ctx context.Context, text string) (string, error) {
+	var out string
+	for len(text) > 0 {
+		chunk := text
+		if len(chunk) > comprehendMaxBytes {
+			chunk = chunk[:comprehendMaxBytes]
+		}
+		text = text[len(chunk):]
+		scrubbed, err := c.redactChunk(ctx, chunk)
+		if err != nil {
+			return "", err
+		}
+		out += scrubbed
+	}
+	return out, nil
+}
+
+func (c *Comprehend) redactChunk(ctx context.Context, text string) (string, error) {
+	resp, err := c.client.DetectPiiEntities(ctx, &comprehend.DetectPiiEntitiesInput{
+		Text:         &text,
+		LanguageCode: types.LanguageCodeEn,
+	})
+	if err != nil {
+		return "", fmt.Errorf("redact: comprehend: %w", err)
+	}
+	entities := resp.Entities
+	// Replace from the end so earlier offsets stay valid.
+	sort.Slice(entities, func(i, j int) bool {
+		return *entities[i].BeginOffset > *entities[j].BeginOffset
+	})
+	for _, e := range entities {
+		begin, end := int(*e.BeginOffset), int(*e.EndOffset)
+		if begin < 0 || end > len(text) || begin >= end {
+			continue
+		}
+		text = text[:begin] + "[REDACTED:" + string(e.Type) + "]" + text[end:]
+	}
+	return text, nil
+}
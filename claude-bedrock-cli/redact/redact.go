@@ -0,0 +1,89 @@
+// Package redact scrubs sensitive content from text before it is persisted,
+// so stored transcripts and audit records never contain raw PII even when
+// the live response did. It deliberately runs at the persistence boundary:
+// what the user sees on screen is untouched.
+package redact
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// Redactor replaces sensitive spans in text with placeholders.
+type Redactor interface {
+	Redact(ctx context.Context, text string) (string, error)
+}
+
+// Func adapts a plain function to a Redactor, for custom scrubbing logic.
+type Func func(ctx context.Context, text string) (string, error)
+
+// Redact implements Redactor.
+func (f Func) Redact(ctx context.Context, text string) (string, error) {
+	return f(ctx, text)
+}
+
+// Chain applies redactors in order; each sees the previous one's output.
+type Chain []Redactor
+
+// Redact implements Redactor.
+func (c Chain) Redact(ctx context.Context, text string) (string, error) {
+	var err error
+	for _, r := range c {
+		if text, err = r.Redact(ctx, text); err != nil {
+			return "", err
+		}
+	}
+	return text, nil
+}
+
+// Patterns is a regex redactor: every match of a pattern is replaced by
+// [REDACTED:<label>].
+type Patterns struct {
+	labels   []string
+	compiled map[string]*regexp.Regexp
+}
+
+// NewPatterns compiles a label → regex map into a redactor. Labels are
+// applied in sorted order so output is deterministic.
+func NewPatterns(patterns map[string]string) (*Patterns, error) {
+	p := &Patterns{compiled: make(map[string]*regexp.Regexp, len(patterns))}
+	for label, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("redact: pattern %s: %w", label, err)
+		}
+		p.labels = append(p.labels, label)
+		p.compiled[label] = re
+	}
+	sort.Strings(p.labels)
+	return p, nil
+}
+
+// Builtin returns a pattern redactor covering common PII and credential
+// shapes: email addresses, US phone numbers and SSNs, payment card numbers,
+// and AWS access key IDs. It is a floor, not a guarantee — combine it with
+// the Comprehend redactor when completeness matters.
+func Builtin() *Patterns {
+	p, err := NewPatterns(map[string]string{
+		"email":   `[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`,
+		"phone":   `\b(?:\+?1[-. ]?)?\(?\d{3}\)?[-. ]\d{3}[-. ]\d{4}\b`,
+		"ssn":     `\b\d{3}-\d{2}-\d{4}\b`,
+		"card":    `\b(?:\d[ -]?){13,16}\b`,
+		"aws-key": `\bAKIA[0-9A-Z]{16}\b`,
+	})
+	if err != nil {
+		// The patterns are literals; a compile failure is a programming error.
+		panic(err)
+	}
+	return p
+}
+
+// Redact implements Redactor.
+func (p *Patterns) Redact(ctx context.Context, text string) (string, error) {
+	for _, label := range p.labels {
+		text = p.compiled[label].ReplaceAllString(text, "[REDACTED:"+label+"]")
+	}
+	return text, nil
+}
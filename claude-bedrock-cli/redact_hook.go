@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/redact"
+)
+
+// Redaction of persisted content is opt-in: CLAUDE_CLI_REDACT=1 scrubs
+// transcripts and audit records with the built-in PII patterns before they
+// are written. CLAUDE_CLI_REDACT_PII=1 additionally runs Amazon Comprehend
+// PII detection (one API call per stored text), and CLAUDE_CLI_REDACT_PATTERNS
+// adds custom rules as semicolon-separated label=regex pairs. The live
+// response shown to the user is never touched.
+func redactEnabled() bool {
+	return os.Getenv("CLAUDE_CLI_REDACT") == "1"
+}
+
+var (
+	redactOnce  sync.Once
+	redactChain redact.Redactor
+)
+
+// storageRedactor builds the configured redaction chain once per process.
+func storageRedactor(ctx context.Context) redact.Redactor {
+	redactOnce.Do(func() {
+		chain := redact.Chain{redact.Builtin()}
+		if raw := os.Getenv("CLAUDE_CLI_REDACT_PATTERNS"); raw != "" {
+			patterns := make(map[string]string)
+			for _, pair := range strings.Split(raw, ";") {
+				label, pattern, ok := strings.Cut(pair, "=")
+				if !ok {
+					log.Printf("warning: redact: malformed CLAUDE_CLI_REDACT_PATTERNS entry %q", pair)
+					continue
+				}
+				patterns[label] = pattern
+			}
+			custom, err := redact.NewPatterns(patterns)
+			if err != nil {
+				log.Printf("warning: %v", err)
+			} else {
+				chain = append(chain, custom)
+			}
+		}
+		if os.Getenv("CLAUDE_CLI_REDACT_PII") == "1" {
+			pii, err := redact.NewComprehend(ctx, "")
+			if err != nil {
+				log.Printf("warning: redact: %v", err)
+			} else {
+				chain = append(chain, pii)
+			}
+		}
+		redactChain = chain
+	})
+	return redactChain
+}
+
+// redactForStorage scrubs text destined for a transcript or audit record.
+// When redaction is disabled it returns the text unchanged; when a redactor
+// fails the content is withheld entirely rather than stored raw.
+func redactForStorage(ctx context.Context, text string) string {
+	if !redactEnabled() || text == "" {
+		return text
+	}
+	scrubbed, err := storageRedactor(ctx).Redact(ctx, text)
+	if err != nil {
+		log.Printf("warning: %v", err)
+		return "[content withheld: redaction failed]"
+	}
+	return scrubbed
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+)
+
+// refineResult carries each stage's intermediate output from the
+// draft-critique-revise pipeline.
+type refineResult struct {
+	Draft    string
+	Critique string
+	Final    string
+}
+
+// draftCritiqueRevise generates a draft with drafter, critiques it against
+// the user's criteria with critic (typically a cheaper model), and has
+// drafter revise the draft to address the critique.
+func draftCritiqueRevise(ctx context.Context, drafter, critic *claude.Client, prompt, criteria string, maxTokens int32) (*refineResult, error) {
+	res := &refineResult{}
+
+	draft, err := drafter.Converse(ctx, claude.Request{
+		Messages:  []claude.Message{claude.UserMessage(prompt)},
+		MaxTokens: maxTokens,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("draft stage: %w", err)
+	}
+	res.Draft = draft
+
+	critiquePrompt := "<task>\n" + prompt + "\n</task>\n\n<draft>\n" + draft + "\n</draft>\n\n" +
+		"Critique the draft against these criteria:\n" + criteria + "\n\n" +
+		"List concrete, actionable problems. Do not rewrite the draft."
+	critique, err := critic.Converse(ctx, claude.Request{
+		System:    "You are a rigorous editor. Identify weaknesses precisely and briefly.",
+		Messages:  []claude.Message{claude.UserMessage(critiquePrompt)},
+		MaxTokens: maxTokens,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("critique stage: %w", err)
+	}
+	res.Critique = critique
+
+	revisePrompt := "<task>\n" + prompt + "\n</task>\n\n<draft>\n" + draft + "\n</draft>\n\n<critique>\n" + critique + "\n</critique>\n\n" +
+		"Rewrite the draft so it fully addresses the critique. Output only the revised text."
+	final, err := drafter.Converse(ctx, claude.Request{
+		Messages:  []claude.Message{claude.UserMessage(revisePrompt)},
+		MaxTokens: maxTokens,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("revise stage: %w", err)
+	}
+	res.Final = final
+	return res, nil
+}
+
+func (r *refineResult) printIntermediate() {
+	fmt.Fprintf(os.Stderr, "--- draft ---\n%s\n--- critique ---\n%s\n--- final ---\n", r.Draft, r.Critique)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+)
+
+// Routing tiers, cheapest to strongest.
+var routeModels = map[string]string{
+	"simple":  "anthropic.claude-3-haiku-20240307-v1:0",
+	"medium":  "anthropic.claude-3-5-sonnet-20241022-v2:0",
+	"complex": "anthropic.claude-3-opus-20240229-v1:0",
+}
+
+var reasoningHints = []string{
+	"prove", "derive", "step by step", "analyze", "trade-off", "tradeoff",
+	"architecture", "debug", "why", "compare", "optimize",
+}
+
+// classifyPrompt buckets a prompt into simple/medium/complex using cheap
+// local signals: length, presence of code, and reasoning-style wording.
+func classifyPrompt(prompt string) string {
+	score := 0
+	if len(prompt) > 2000 {
+		score++
+	}
+	if len(prompt) > 12000 {
+		score++
+	}
+	if strings.Contains(prompt, "```") || strings.Contains(prompt, "func ") || strings.Contains(prompt, "def ") {
+		score++
+	}
+	lower := strings.ToLower(prompt)
+	for _, hint := range reasoningHints {
+		if strings.Contains(lower, hint) {
+			score++
+			break
+		}
+	}
+	switch {
+	case score == 0:
+		return "simple"
+	case score == 1:
+		return "medium"
+	default:
+		return "complex"
+	}
+}
+
+// classifyPromptLLM asks a Haiku model to bucket the prompt, falling back to
+// the local heuristic when the verdict is unusable.
+func classifyPromptLLM(ctx context.Context, region, prompt string) string {
+	client, err := claude.NewClient(ctx, region, routeModels["simple"])
+	if err != nil {
+		return classifyPrompt(prompt)
+	}
+	sample := prompt
+	if len(sample) > 4000 {
+		sample = sample[:4000]
+	}
+	verdict, err := client.Converse(ctx, claude.Request{
+		System:    "Classify how much reasoning capability the following task needs. Reply with exactly one word: simple, medium, or complex.",
+		Messages:  []claude.Message{claude.UserMessage(sample)},
+		MaxTokens: 8,
+	})
+	if err != nil {
+		return classifyPrompt(prompt)
+	}
+	tier := strings.ToLower(strings.TrimSpace(verdict))
+	if _, ok := routeModels[tier]; !ok {
+		return classifyPrompt(prompt)
+	}
+	return tier
+}
+
+// routeModel picks a model for the prompt and logs the decision. useLLM
+// selects the Haiku-based classifier instead of the local heuristic.
+func routeModel(ctx context.Context, region, prompt string, useLLM bool) string {
+	var tier string
+	if useLLM {
+		tier = classifyPromptLLM(ctx, region, prompt)
+	} else {
+		tier = classifyPrompt(prompt)
+	}
+	model := routeModels[tier]
+	fmt.Fprintf(os.Stderr, "routing: classified as %s, using %s\n", tier, model)
+	return model
+}
@@ -0,0 +1,196 @@
+// Package schedule runs configured prompts and pipelines on cron
+// schedules and hands their results to a delivery hook.
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"gopkg.in/yaml.v3"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/pipeline"
+)
+
+// Job is one recurring task: a cron expression plus either an inline
+// prompt or a pipeline definition file. Input, when set, names a file
+// read fresh on every run and wrapped as a <document> for prompts (or
+// passed as the pipeline input).
+type Job struct {
+	Name      string `yaml:"name"`
+	Cron      string `yaml:"cron"`
+	Prompt    string `yaml:"prompt,omitempty"`
+	Pipeline  string `yaml:"pipeline,omitempty"`
+	Model     string `yaml:"model,omitempty"`
+	System    string `yaml:"system,omitempty"`
+	Input     string `yaml:"input,omitempty"`
+	MaxTokens int32  `yaml:"max_tokens,omitempty"`
+	// Sink names where the result goes; the runner's Deliver hook
+	// interprets it. Empty means stdout.
+	Sink string `yaml:"sink,omitempty"`
+	// Subject is a template for the delivery subject line; {{job}},
+	// {{status}}, and {{time}} are substituted. Empty applies a default.
+	Subject string `yaml:"subject,omitempty"`
+}
+
+// Config is the schedule definition file.
+type Config struct {
+	Jobs []Job `yaml:"jobs"`
+}
+
+// LoadConfig reads and validates a schedule definition.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var c Config
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("schedule %s: %w", path, err)
+	}
+	if len(c.Jobs) == 0 {
+		return nil, fmt.Errorf("schedule %s: no jobs defined", path)
+	}
+	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	seen := map[string]bool{}
+	for i, job := range c.Jobs {
+		if job.Name == "" {
+			return nil, fmt.Errorf("schedule %s: job %d needs a name", path, i+1)
+		}
+		if seen[job.Name] {
+			return nil, fmt.Errorf("schedule %s: duplicate job name %q", path, job.Name)
+		}
+		seen[job.Name] = true
+		if _, err := parser.Parse(job.Cron); err != nil {
+			return nil, fmt.Errorf("schedule %s: job %s: bad cron expression %q: %w", path, job.Name, job.Cron, err)
+		}
+		if (job.Prompt == "") == (job.Pipeline == "") {
+			return nil, fmt.Errorf("schedule %s: job %s needs exactly one of prompt or pipeline", path, job.Name)
+		}
+	}
+	return &c, nil
+}
+
+// Runner executes scheduled jobs against one client.
+type Runner struct {
+	Client *claude.Client
+	Jobs   []Job
+	// Deliver receives each job's output; nil prints to stdout. Delivery
+	// failures are reported through OnError.
+	Deliver func(ctx context.Context, job Job, output string) error
+	// OnError observes job failures; nil logs to stderr.
+	OnError func(job Job, err error)
+	// Notify observes job lifecycle transitions ("started", then
+	// "succeeded" or "failed") with usage and duration; nil disables it.
+	Notify func(ctx context.Context, job Job, status string, usage claude.Usage, duration time.Duration, err error)
+}
+
+// Start registers the jobs and runs the cron loop until ctx is cancelled.
+func (r *Runner) Start(ctx context.Context) error {
+	c := cron.New()
+	for _, job := range r.Jobs {
+		job := job
+		_, err := c.AddFunc(job.Cron, func() { r.runJob(ctx, job) })
+		if err != nil {
+			return fmt.Errorf("schedule: job %s: %w", job.Name, err)
+		}
+	}
+	c.Start()
+	<-ctx.Done()
+	// Let an in-flight job finish rather than cutting it off mid-call.
+	stopped := c.Stop()
+	select {
+	case <-stopped.Done():
+	case <-time.After(30 * time.Second):
+	}
+	return ctx.Err()
+}
+
+// RunJobNow executes the named job once, outside its schedule.
+func (r *Runner) RunJobNow(ctx context.Context, name string) error {
+	for _, job := range r.Jobs {
+		if job.Name == name {
+			r.runJob(ctx, job)
+			return nil
+		}
+	}
+	return fmt.Errorf("schedule: no job named %q", name)
+}
+
+func (r *Runner) runJob(ctx context.Context, job Job) {
+	if r.Notify != nil {
+		r.Notify(ctx, job, "started", claude.Usage{}, 0, nil)
+	}
+	start := time.Now()
+	output, usage, err := r.execute(ctx, job)
+	if err != nil {
+		if r.Notify != nil {
+			r.Notify(ctx, job, "failed", usage, time.Since(start), err)
+		}
+		r.fail(job, err)
+		return
+	}
+	if r.Notify != nil {
+		r.Notify(ctx, job, "succeeded", usage, time.Since(start), nil)
+	}
+	if r.Deliver == nil {
+		fmt.Printf("=== %s (%s) ===\n%s\n", job.Name, time.Now().Format(time.RFC3339), output)
+		return
+	}
+	if err := r.Deliver(ctx, job, output); err != nil {
+		r.fail(job, fmt.Errorf("delivering: %w", err))
+	}
+}
+
+func (r *Runner) execute(ctx context.Context, job Job) (string, claude.Usage, error) {
+	input := ""
+	if job.Input != "" {
+		data, err := os.ReadFile(job.Input)
+		if err != nil {
+			return "", claude.Usage{}, err
+		}
+		input = string(data)
+	}
+
+	client := r.Client
+	if job.Model != "" {
+		client = client.WithModel(job.Model)
+	}
+	maxTokens := job.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = 4096
+	}
+
+	if job.Pipeline != "" {
+		p, err := pipeline.Load(job.Pipeline)
+		if err != nil {
+			return "", claude.Usage{}, err
+		}
+		// Pipeline runs do not surface per-stage usage yet.
+		_, result, err := p.Run(ctx, client, input, nil)
+		return result, claude.Usage{}, err
+	}
+
+	prompt := job.Prompt
+	if input != "" {
+		prompt = "<document>\n" + input + "\n</document>\n\n" + prompt
+	}
+	out, usage, err := client.ConverseUsage(ctx, claude.Request{
+		System:    job.System,
+		Messages:  []claude.Message{claude.UserMessage(prompt)},
+		MaxTokens: maxTokens,
+	})
+	return strings.TrimSpace(out), usage, err
+}
+
+func (r *Runner) fail(job Job, err error) {
+	if r.OnError != nil {
+		r.OnError(job, err)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "schedule: job %s: %v\n", job.Name, err)
+}
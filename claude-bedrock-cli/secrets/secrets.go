@@ -0,0 +1,120 @@
+// Package secrets resolves configuration values referenced as
+// secretsmanager:// or ssm:// URIs, so prompts, tool API keys, and tenant
+// credentials never have to live in plain config files.
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// DefaultTTL bounds how long a resolved value is reused before the backing
+// store is consulted again, so rotated secrets propagate without a restart.
+const DefaultTTL = 5 * time.Minute
+
+// IsRef reports whether the value is a secret reference rather than a
+// literal.
+func IsRef(value string) bool {
+	return strings.HasPrefix(value, "secretsmanager://") || strings.HasPrefix(value, "ssm://")
+}
+
+// Resolver fetches and caches secret references.
+type Resolver struct {
+	sm  *secretsmanager.Client
+	ssm *ssm.Client
+
+	// TTL overrides DefaultTTL when positive.
+	TTL time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	value   string
+	fetched time.Time
+}
+
+// NewResolver builds a Resolver for the region using the default credential
+// chain.
+func NewResolver(ctx context.Context, region string) (*Resolver, error) {
+	var opts []func(*awsconfig.LoadOptions) error
+	if region != "" {
+		opts = append(opts, awsconfig.WithRegion(region))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return &Resolver{
+		sm:    secretsmanager.NewFromConfig(cfg),
+		ssm:   ssm.NewFromConfig(cfg),
+		cache: make(map[string]cacheEntry),
+	}, nil
+}
+
+// Resolve returns the value behind a secretsmanager:// or ssm:// reference,
+// or the input unchanged when it is a literal. Resolved values are cached
+// for the resolver's TTL.
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	if !IsRef(value) {
+		return value, nil
+	}
+	ttl := r.TTL
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	r.mu.Lock()
+	if entry, ok := r.cache[value]; ok && time.Since(entry.fetched) < ttl {
+		r.mu.Unlock()
+		return entry.value, nil
+	}
+	r.mu.Unlock()
+
+	resolved, err := r.fetch(ctx, value)
+	if err != nil {
+		return "", err
+	}
+	r.mu.Lock()
+	r.cache[value] = cacheEntry{value: resolved, fetched: time.Now()}
+	r.mu.Unlock()
+	return resolved, nil
+}
+
+func (r *Resolver) fetch(ctx context.Context, ref string) (string, error) {
+	switch {
+	case strings.HasPrefix(ref, "secretsmanager://"):
+		name := strings.TrimPrefix(ref, "secretsmanager://")
+		out, err := r.sm.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+			SecretId: aws.String(name),
+		})
+		if err != nil {
+			return "", fmt.Errorf("resolving %s: %w", ref, err)
+		}
+		return aws.ToString(out.SecretString), nil
+	case strings.HasPrefix(ref, "ssm://"):
+		name := strings.TrimPrefix(ref, "ssm://")
+		if !strings.HasPrefix(name, "/") {
+			name = "/" + name
+		}
+		out, err := r.ssm.GetParameter(ctx, &ssm.GetParameterInput{
+			Name:           aws.String(name),
+			WithDecryption: aws.Bool(true),
+		})
+		if err != nil {
+			return "", fmt.Errorf("resolving %s: %w", ref, err)
+		}
+		return aws.ToString(out.Parameter.Value), nil
+	default:
+		return "", fmt.Errorf("unsupported secret reference %s", ref)
+	}
+}
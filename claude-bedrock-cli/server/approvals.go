@@ -0,0 +1,111 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultApprovalTimeout denies a pending tool call that no operator
+// decides in time.
+const DefaultApprovalTimeout = 5 * time.Minute
+
+// pendingApproval is one tool call waiting on a human decision.
+type pendingApproval struct {
+	ID      string    `json:"id"`
+	Tool    string    `json:"tool"`
+	Input   string    `json:"input"`
+	Created time.Time `json:"created"`
+
+	decision chan bool
+}
+
+// Approvals is a pending-approval queue exposed through the server API.
+// An agentic caller blocks in Confirm until an operator approves or
+// rejects the call via POST /v1/approvals/{id}; undecided calls are
+// denied after Timeout. It satisfies the agent package's Confirm callback
+// signature, so it drops in wherever a terminal prompt would.
+type Approvals struct {
+	// Timeout bounds how long Confirm waits; 0 applies
+	// DefaultApprovalTimeout.
+	Timeout time.Duration
+
+	mu      sync.Mutex
+	seq     int
+	pending map[string]*pendingApproval
+}
+
+// NewApprovals returns an empty approval queue.
+func NewApprovals() *Approvals {
+	return &Approvals{pending: make(map[string]*pendingApproval)}
+}
+
+// Confirm records the tool call as pending and blocks until it is decided
+// or times out. It is safe for concurrent use by multiple agent runs.
+func (a *Approvals) Confirm(tool, input string) bool {
+	a.mu.Lock()
+	a.seq++
+	p := &pendingApproval{
+		ID:       fmt.Sprintf("apr-%d", a.seq),
+		Tool:     tool,
+		Input:    input,
+		Created:  time.Now(),
+		decision: make(chan bool, 1),
+	}
+	a.pending[p.ID] = p
+	a.mu.Unlock()
+	defer func() {
+		a.mu.Lock()
+		delete(a.pending, p.ID)
+		a.mu.Unlock()
+	}()
+
+	timeout := a.Timeout
+	if timeout <= 0 {
+		timeout = DefaultApprovalTimeout
+	}
+	select {
+	case ok := <-p.decision:
+		return ok
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// handleList reports pending approvals, oldest first.
+func (a *Approvals) handleList(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	list := make([]*pendingApproval, 0, len(a.pending))
+	for _, p := range a.pending {
+		list = append(list, p)
+	}
+	a.mu.Unlock()
+	sort.Slice(list, func(i, j int) bool { return list[i].Created.Before(list[j].Created) })
+	writeJSON(w, http.StatusOK, map[string]any{"pending": list})
+}
+
+// handleDecide resolves one pending approval: {"approve": true} resumes
+// the waiting agent with permission, anything else with a denial.
+func (a *Approvals) handleDecide(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Approve bool `json:"approve"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		httpError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+	id := r.PathValue("id")
+	a.mu.Lock()
+	p := a.pending[id]
+	delete(a.pending, id)
+	a.mu.Unlock()
+	if p == nil {
+		httpError(w, http.StatusNotFound, "no pending approval "+id)
+		return
+	}
+	p.decision <- body.Approve
+	writeJSON(w, http.StatusOK, map[string]any{"id": id, "approved": body.Approve})
+}
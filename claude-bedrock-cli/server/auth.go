@@ -0,0 +1,192 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+)
+
+// Tenant is one API consumer with its own key, rate limit, and model
+// allow-list.
+type Tenant struct {
+	Name   string   `json:"name"`
+	APIKey string   `json:"api_key"`
+	RPM    int      `json:"rpm"`    // requests per minute; 0 = unlimited
+	Models []string `json:"models"` // allowed model ID substrings; empty = any
+	// MaxTokens caps output tokens per request for this tenant; 0 applies
+	// the server-wide ceiling only.
+	MaxTokens int32 `json:"max_tokens,omitempty"`
+	// Principal identifies the tenant for IAM (ARN substring) or Cognito
+	// (subject, username, or group) authentication modes.
+	Principal string `json:"principal,omitempty"`
+	// RoleARN, when set, is assumed before invoking Bedrock for this tenant,
+	// so the server draws on the tenant's own account quota rather than its
+	// own. ExternalID is passed to STS when the trust policy demands one.
+	RoleARN    string `json:"role_arn,omitempty"`
+	ExternalID string `json:"external_id,omitempty"`
+
+	limiter *rateLimiter
+	client  *claude.Client
+
+	mu           sync.Mutex
+	requests     int64
+	inputTokens  int64
+	outputTokens int64
+}
+
+// ModelAllowed reports whether the tenant may invoke the model.
+func (t *Tenant) ModelAllowed(modelID string) bool {
+	if len(t.Models) == 0 {
+		return true
+	}
+	for _, allowed := range t.Models {
+		if strings.Contains(modelID, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *Tenant) recordUsage(u claude.Usage) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.requests++
+	t.inputTokens += int64(u.InputTokens)
+	t.outputTokens += int64(u.OutputTokens)
+}
+
+func (t *Tenant) usageTotals() (in, out, requests int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.inputTokens, t.outputTokens, t.requests
+}
+
+// Authenticator resolves request credentials to a tenant.
+type Authenticator struct {
+	byKey map[string]*Tenant
+	all   []*Tenant
+}
+
+// LoadTenants reads a JSON file of the form {"tenants": [...]} and returns
+// an Authenticator over it.
+func LoadTenants(path string) (*Authenticator, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg struct {
+		Tenants []*Tenant `json:"tenants"`
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("tenants %s: %w", path, err)
+	}
+	if len(cfg.Tenants) == 0 {
+		return nil, fmt.Errorf("tenants %s: no tenants defined", path)
+	}
+	auth := &Authenticator{byKey: make(map[string]*Tenant)}
+	for _, t := range cfg.Tenants {
+		if t.Name == "" {
+			return nil, fmt.Errorf("tenants %s: every tenant needs a name", path)
+		}
+		if t.APIKey == "" && t.Principal == "" {
+			return nil, fmt.Errorf("tenants %s: tenant %s needs api_key or principal", path, t.Name)
+		}
+		if _, dup := auth.byKey[t.APIKey]; t.APIKey != "" && dup {
+			return nil, fmt.Errorf("tenants %s: duplicate api_key for %s", path, t.Name)
+		}
+		t.limiter = newRateLimiter(t.RPM)
+		if t.APIKey != "" {
+			auth.byKey[t.APIKey] = t
+		}
+		auth.all = append(auth.all, t)
+	}
+	return auth, nil
+}
+
+// Tenants returns all configured tenants, for authenticators that match on
+// principals rather than API keys.
+func (a *Authenticator) Tenants() []*Tenant {
+	return a.all
+}
+
+// ResolveSecrets rewrites tenant API keys through resolve, so the tenants
+// file can hold secretsmanager:// or ssm:// references instead of literal
+// keys. The key lookup table is rebuilt around the resolved values.
+func (a *Authenticator) ResolveSecrets(ctx context.Context, resolve func(context.Context, string) (string, error)) error {
+	byKey := make(map[string]*Tenant, len(a.byKey))
+	for _, t := range a.all {
+		if t.APIKey == "" {
+			continue
+		}
+		key, err := resolve(ctx, t.APIKey)
+		if err != nil {
+			return fmt.Errorf("tenant %s: %w", t.Name, err)
+		}
+		if _, dup := byKey[key]; dup {
+			return fmt.Errorf("tenant %s: duplicate api_key after secret resolution", t.Name)
+		}
+		t.APIKey = key
+		byKey[key] = t
+	}
+	a.byKey = byKey
+	return nil
+}
+
+// Authenticate extracts the API key from X-Api-Key or a Bearer token and
+// returns the matching tenant.
+func (a *Authenticator) Authenticate(r *http.Request) (*Tenant, error) {
+	key := r.Header.Get("X-Api-Key")
+	if key == "" {
+		if bearer := r.Header.Get("Authorization"); strings.HasPrefix(bearer, "Bearer ") {
+			key = strings.TrimPrefix(bearer, "Bearer ")
+		}
+	}
+	if key == "" {
+		return nil, fmt.Errorf("missing API key (X-Api-Key or Authorization: Bearer)")
+	}
+	for candidate, tenant := range a.byKey {
+		if subtle.ConstantTimeCompare([]byte(candidate), []byte(key)) == 1 {
+			return tenant, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown API key")
+}
+
+// rateLimiter is a minute-granularity token bucket.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rpm    int
+	count  int
+	window time.Time
+}
+
+func newRateLimiter(rpm int) *rateLimiter {
+	return &rateLimiter{rpm: rpm}
+}
+
+// Allow consumes one request slot, resetting the bucket each minute.
+func (rl *rateLimiter) Allow() bool {
+	if rl.rpm <= 0 {
+		return true
+	}
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	now := time.Now()
+	if now.Sub(rl.window) >= time.Minute {
+		rl.window = now
+		rl.count = 0
+	}
+	if rl.count >= rl.rpm {
+		return false
+	}
+	rl.count++
+	return true
+}
@@ -0,0 +1,282 @@
+package server
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PresignedIdentityHeader carries a presigned sts:GetCallerIdentity URL; the
+// server resolves it to the caller's IAM ARN, the same pattern used by
+// aws-iam-authenticator. This lets the proxy require AWS identities without
+// ever seeing long-term credentials.
+const PresignedIdentityHeader = "X-Amz-Presigned-Getcalleridentity"
+
+// IAMAuth authenticates requests by resolving a presigned GetCallerIdentity
+// URL and matching the caller ARN against tenant principals.
+type IAMAuth struct {
+	Tenants    []*Tenant
+	HTTPClient *http.Client
+}
+
+// Authenticate implements the server auth interface for IAM identities.
+func (a *IAMAuth) Authenticate(r *http.Request) (*Tenant, error) {
+	raw := r.Header.Get(PresignedIdentityHeader)
+	if raw == "" {
+		return nil, fmt.Errorf("missing %s header", PresignedIdentityHeader)
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid presigned URL")
+	}
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf("presigned URL must use https")
+	}
+	if !validSTSHost(strings.ToLower(u.Hostname())) {
+		return nil, fmt.Errorf("presigned URL must target AWS STS")
+	}
+	if u.Query().Get("Action") != "GetCallerIdentity" {
+		return nil, fmt.Errorf("presigned URL must be for GetCallerIdentity")
+	}
+
+	hc := a.HTTPClient
+	if hc == nil {
+		hc = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := hc.Get(u.String())
+	if err != nil {
+		return nil, fmt.Errorf("resolving caller identity: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("caller identity rejected by STS (%s)", resp.Status)
+	}
+	var identity struct {
+		Result struct {
+			Arn string `xml:"Arn"`
+		} `xml:"GetCallerIdentityResult"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&identity); err != nil {
+		return nil, fmt.Errorf("parsing STS response: %w", err)
+	}
+	arn := identity.Result.Arn
+	for _, t := range a.Tenants {
+		if t.Principal != "" && principalMatches(arn, t.Principal) {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("no tenant configured for principal %s", arn)
+}
+
+// validSTSHost accepts exactly the global STS endpoint or a regional one
+// (sts.<region>.amazonaws.com with a plain region label). Anything looser —
+// dotted bucket hosts, lookalike subdomains — is an authentication bypass,
+// since the attacker then controls the GetCallerIdentity response.
+func validSTSHost(host string) bool {
+	if host == "sts.amazonaws.com" {
+		return true
+	}
+	region, ok := strings.CutPrefix(host, "sts.")
+	if !ok {
+		return false
+	}
+	if region, ok = strings.CutSuffix(region, ".amazonaws.com"); !ok {
+		return false
+	}
+	if region == "" || len(region) > 32 {
+		return false
+	}
+	for i := 0; i < len(region); i++ {
+		c := region[i]
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// principalMatches compares the caller ARN against a configured principal:
+// either the full ARN matches exactly, or the resource portion does, with
+// assumed-role session ARNs normalized to their role so "role/name" matches
+// any session. Exact comparison only — substring matching would let
+// user/bobby authenticate as user/bob.
+func principalMatches(arn, principal string) bool {
+	if arn == principal {
+		return true
+	}
+	i := strings.LastIndexByte(arn, ':')
+	if i < 0 {
+		return false
+	}
+	resource := arn[i+1:]
+	// arn:aws:sts::<account>:assumed-role/<role>/<session> -> role/<role>
+	if rest, ok := strings.CutPrefix(resource, "assumed-role/"); ok {
+		role, _, _ := strings.Cut(rest, "/")
+		resource = "role/" + role
+	}
+	return resource == principal
+}
+
+// CognitoAuth validates Cognito user pool JWTs against the pool's JWKS and
+// matches the token subject or group against tenant principals.
+type CognitoAuth struct {
+	Region     string
+	UserPoolID string
+	Tenants    []*Tenant
+	HTTPClient *http.Client
+
+	mu   sync.Mutex
+	keys map[string]*rsa.PublicKey // kid -> key
+}
+
+func (a *CognitoAuth) issuer() string {
+	return fmt.Sprintf("https://cognito-idp.%s.amazonaws.com/%s", a.Region, a.UserPoolID)
+}
+
+// Authenticate implements the server auth interface for Cognito JWTs.
+func (a *CognitoAuth) Authenticate(r *http.Request) (*Tenant, error) {
+	bearer := r.Header.Get("Authorization")
+	if !strings.HasPrefix(bearer, "Bearer ") {
+		return nil, fmt.Errorf("missing bearer token")
+	}
+	claims, err := a.verify(strings.TrimPrefix(bearer, "Bearer "))
+	if err != nil {
+		return nil, err
+	}
+
+	principals := []string{claims.Sub, claims.Username}
+	principals = append(principals, claims.Groups...)
+	for _, t := range a.Tenants {
+		if t.Principal == "" {
+			continue
+		}
+		for _, p := range principals {
+			if p != "" && p == t.Principal {
+				return t, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("no tenant configured for subject %s", claims.Sub)
+}
+
+type cognitoClaims struct {
+	Sub      string   `json:"sub"`
+	Iss      string   `json:"iss"`
+	Exp      int64    `json:"exp"`
+	TokenUse string   `json:"token_use"`
+	Username string   `json:"cognito:username"`
+	Groups   []string `json:"cognito:groups"`
+}
+
+// verify checks the token's RS256 signature, issuer, and expiry.
+func (a *CognitoAuth) verify(token string) (*cognitoClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed JWT")
+	}
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("malformed JWT header")
+	}
+	var header struct {
+		Kid string `json:"kid"`
+		Alg string `json:"alg"`
+	}
+	if err := json.Unmarshal(headerJSON, &header); err != nil || header.Alg != "RS256" {
+		return nil, fmt.Errorf("unsupported JWT header")
+	}
+	key, err := a.keyFor(header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed JWT signature")
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig); err != nil {
+		return nil, fmt.Errorf("invalid JWT signature")
+	}
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("malformed JWT claims")
+	}
+	var claims cognitoClaims
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		return nil, fmt.Errorf("malformed JWT claims")
+	}
+	if claims.Iss != a.issuer() {
+		return nil, fmt.Errorf("JWT issuer mismatch")
+	}
+	if time.Now().Unix() >= claims.Exp {
+		return nil, fmt.Errorf("JWT expired")
+	}
+	return &claims, nil
+}
+
+// keyFor returns the pool's public key for kid, fetching and caching the
+// JWKS on first use or on unknown kid (key rotation).
+func (a *CognitoAuth) keyFor(kid string) (*rsa.PublicKey, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if key, ok := a.keys[kid]; ok {
+		return key, nil
+	}
+
+	hc := a.HTTPClient
+	if hc == nil {
+		hc = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := hc.Get(a.issuer() + "/.well-known/jwks.json")
+	if err != nil {
+		return nil, fmt.Errorf("fetching JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("fetching JWKS: %w", err)
+	}
+	var jwks struct {
+		Keys []struct {
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(body, &jwks); err != nil {
+		return nil, fmt.Errorf("parsing JWKS: %w", err)
+	}
+	a.keys = make(map[string]*rsa.PublicKey, len(jwks.Keys))
+	for _, k := range jwks.Keys {
+		nBytes, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			continue
+		}
+		eBytes, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			continue
+		}
+		a.keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(nBytes),
+			E: int(new(big.Int).SetBytes(eBytes).Int64()),
+		}
+	}
+	key, ok := a.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("JWT signed with unknown key %s", kid)
+	}
+	return key, nil
+}
@@ -0,0 +1,52 @@
+package server
+
+import "testing"
+
+func TestValidSTSHost(t *testing.T) {
+	valid := []string{
+		"sts.amazonaws.com",
+		"sts.us-east-1.amazonaws.com",
+		"sts.eu-central-1.amazonaws.com",
+	}
+	for _, host := range valid {
+		if !validSTSHost(host) {
+			t.Errorf("validSTSHost(%q) = false, want true", host)
+		}
+	}
+	invalid := []string{
+		"sts.x.s3.amazonaws.com",                   // dotted S3 bucket inside the pattern
+		"sts.us-east-1.amazonaws.com.evil.example", // suffix spoof
+		"evil-sts.amazonaws.com",
+		"sts.amazonaws.com.example",
+		"sts..amazonaws.com",
+		"s3.amazonaws.com",
+		"sts.US-EAST-1.amazonaws.com", // hostnames are lowercased before the check
+		"",
+	}
+	for _, host := range invalid {
+		if validSTSHost(host) {
+			t.Errorf("validSTSHost(%q) = true, want false", host)
+		}
+	}
+}
+
+func TestPrincipalMatches(t *testing.T) {
+	cases := []struct {
+		arn, principal string
+		want           bool
+	}{
+		{"arn:aws:iam::123456789012:user/bob", "user/bob", true},
+		{"arn:aws:iam::123456789012:user/bob", "arn:aws:iam::123456789012:user/bob", true},
+		{"arn:aws:iam::123456789012:user/bobby", "user/bob", false},
+		{"arn:aws:iam::123456789012:user/bob", "user/bobby", false},
+		{"arn:aws:sts::123456789012:assumed-role/Deploy/session-1", "role/Deploy", true},
+		{"arn:aws:sts::123456789012:assumed-role/Deployer/session-1", "role/Deploy", false},
+		{"arn:aws:iam::123456789012:role/Deploy", "role/Deploy", true},
+		{"arn:aws:iam::123456789012:user/bob", "bob", false},
+	}
+	for _, c := range cases {
+		if got := principalMatches(c.arn, c.principal); got != c.want {
+			t.Errorf("principalMatches(%q, %q) = %v, want %v", c.arn, c.principal, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+)
+
+// metrics collects per-model counters exposed in Prometheus text format on
+// /metrics. The cardinality is bounded by the number of models in use, so a
+// simple mutex-guarded map is sufficient.
+type metrics struct {
+	mu        sync.Mutex
+	perModel  map[string]*modelMetrics
+	startedAt time.Time
+}
+
+type modelMetrics struct {
+	requests     int64
+	errors       int64
+	throttles    int64
+	inputTokens  int64
+	outputTokens int64
+	latencySum   float64 // seconds
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		perModel:  make(map[string]*modelMetrics),
+		startedAt: time.Now(),
+	}
+}
+
+// observe records one completed (or failed) invocation.
+func (m *metrics) observe(model string, latency time.Duration, usage claude.Usage, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	mm := m.perModel[model]
+	if mm == nil {
+		mm = &modelMetrics{}
+		m.perModel[model] = mm
+	}
+	mm.requests++
+	mm.latencySum += latency.Seconds()
+	mm.inputTokens += int64(usage.InputTokens)
+	mm.outputTokens += int64(usage.OutputTokens)
+	if err != nil {
+		mm.errors++
+		if strings.Contains(err.Error(), "ThrottlingException") || strings.Contains(err.Error(), "Too many requests") {
+			mm.throttles++
+		}
+	}
+}
+
+// writePrometheus emits the counters in Prometheus exposition format.
+func (m *metrics) writePrometheus(w io.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	models := make([]string, 0, len(m.perModel))
+	for model := range m.perModel {
+		models = append(models, model)
+	}
+	sort.Strings(models)
+
+	fmt.Fprintf(w, "# HELP claude_proxy_uptime_seconds Time since the server started.\n")
+	fmt.Fprintf(w, "# TYPE claude_proxy_uptime_seconds gauge\n")
+	fmt.Fprintf(w, "claude_proxy_uptime_seconds %f\n", time.Since(m.startedAt).Seconds())
+
+	counter := func(name, help string, value func(*modelMetrics) string) {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+		for _, model := range models {
+			fmt.Fprintf(w, "%s{model=%q} %s\n", name, model, value(m.perModel[model]))
+		}
+	}
+	counter("claude_proxy_requests_total", "Model invocations.",
+		func(mm *modelMetrics) string { return fmt.Sprint(mm.requests) })
+	counter("claude_proxy_errors_total", "Failed model invocations.",
+		func(mm *modelMetrics) string { return fmt.Sprint(mm.errors) })
+	counter("claude_proxy_throttles_total", "Invocations rejected by Bedrock throttling.",
+		func(mm *modelMetrics) string { return fmt.Sprint(mm.throttles) })
+	counter("claude_proxy_input_tokens_total", "Input tokens consumed.",
+		func(mm *modelMetrics) string { return fmt.Sprint(mm.inputTokens) })
+	counter("claude_proxy_output_tokens_total", "Output tokens generated.",
+		func(mm *modelMetrics) string { return fmt.Sprint(mm.outputTokens) })
+	counter("claude_proxy_latency_seconds_sum", "Total invocation latency.",
+		func(mm *modelMetrics) string { return fmt.Sprintf("%f", mm.latencySum) })
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// ReloadableAuth wraps another Auth and lets it be swapped atomically while
+// requests are in flight, so tenant changes apply without a restart.
+type ReloadableAuth struct {
+	current atomic.Value // Auth
+}
+
+// NewReloadableAuth wraps an initial Auth.
+func NewReloadableAuth(a Auth) *ReloadableAuth {
+	r := &ReloadableAuth{}
+	r.current.Store(a)
+	return r
+}
+
+// Authenticate delegates to the most recently loaded Auth.
+func (r *ReloadableAuth) Authenticate(req *http.Request) (*Tenant, error) {
+	return r.current.Load().(Auth).Authenticate(req)
+}
+
+// Set replaces the wrapped Auth for all future requests.
+func (r *ReloadableAuth) Set(a Auth) {
+	r.current.Store(a)
+}
+
+// WatchFile invokes reload whenever path changes. A failing reload is logged
+// and the previous configuration stays in effect; the file keeps being
+// watched so a corrected version applies on the next save. The parent
+// directory is watched rather than the file itself because most editors and
+// config mounts replace the file instead of writing in place.
+func WatchFile(path string, reload func() error) (stop func(), err error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("watching %s: %w", path, err)
+	}
+	dir := filepath.Dir(path)
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("watching %s: %w", dir, err)
+	}
+	base := filepath.Base(path)
+
+	go func() {
+		// Coalesce bursts of events (rename + chmod + write) into one reload.
+		var pending <-chan time.Time
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Base(event.Name) != base {
+					continue
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+					continue
+				}
+				pending = time.After(250 * time.Millisecond)
+			case <-pending:
+				pending = nil
+				if err := reload(); err != nil {
+					log.Printf("server: reload of %s failed, keeping previous version: %v", path, err)
+				} else {
+					log.Printf("server: reloaded %s", path)
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("server: watching %s: %v", path, err)
+			}
+		}
+	}()
+	return func() { watcher.Close() }, nil
+}
@@ -0,0 +1,442 @@
+// Package server exposes Claude on Bedrock as an HTTP service that can be
+// shared safely by multiple teams.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+)
+
+// ChatRequest is the JSON body of POST /v1/chat.
+type ChatRequest struct {
+	Model     string        `json:"model,omitempty"`
+	System    string        `json:"system,omitempty"`
+	Messages  []ChatMessage `json:"messages"`
+	MaxTokens int32         `json:"max_tokens,omitempty"`
+	Stream    bool          `json:"stream,omitempty"`
+}
+
+// ChatMessage is one turn in a ChatRequest.
+type ChatMessage struct {
+	Role string `json:"role"` // "user" or "assistant"
+	Text string `json:"text"`
+}
+
+// ChatResponse is the JSON body of a non-streaming chat completion.
+type ChatResponse struct {
+	Text         string `json:"text"`
+	Model        string `json:"model"`
+	InputTokens  int32  `json:"input_tokens"`
+	OutputTokens int32  `json:"output_tokens"`
+}
+
+// Auth resolves an HTTP request to a tenant; implementations cover static
+// API keys, IAM identities, and Cognito JWTs.
+type Auth interface {
+	Authenticate(r *http.Request) (*Tenant, error)
+}
+
+// Server is the HTTP front end. It reuses one Bedrock client for all
+// requests and enforces per-tenant authentication and quotas.
+type Server struct {
+	client       *claude.Client
+	auth         Auth
+	defaultModel string
+	maxTokens    int32
+	mux          *http.ServeMux
+	metrics      *metrics
+
+	// MaxBodyBytes caps the request body size; 0 applies DefaultMaxBodyBytes.
+	MaxBodyBytes int64
+	// SlowClientTimeout is how long a streamed delta may wait on a slow
+	// client before the upstream Bedrock stream is cancelled.
+	SlowClientTimeout time.Duration
+	// CoalesceWindow batches streamed deltas for up to this long before
+	// emitting an SSE frame, trading a sliver of latency for far fewer
+	// frames to slow clients. 0 disables coalescing.
+	CoalesceWindow time.Duration
+	// DrainTimeout bounds graceful shutdown; 0 applies DefaultDrainTimeout.
+	DrainTimeout time.Duration
+	// Region is the AWS region used when building per-tenant assume-role
+	// clients; empty uses the default resolution chain.
+	Region string
+	// ReadyCheck verifies that Bedrock is reachable with valid credentials;
+	// /readyz reports 503 when it fails. nil means /readyz only checks that
+	// the process is serving.
+	ReadyCheck func(ctx context.Context) error
+	// Approvals queues tool calls awaiting a human decision; see Approvals.
+	Approvals *Approvals
+
+	readyMu  sync.Mutex
+	readyErr error
+	readyAt  time.Time
+}
+
+const (
+	// DefaultMaxBodyBytes bounds request bodies at 10 MiB.
+	DefaultMaxBodyBytes = 10 << 20
+	// defaultSlowClientTimeout is the default stall budget for slow readers.
+	defaultSlowClientTimeout = 30 * time.Second
+	// streamBufferDeltas bounds how many deltas may queue for a slow client
+	// before the stall timer starts mattering.
+	streamBufferDeltas = 256
+)
+
+// New builds a Server around an existing claude.Client. auth may be nil to
+// run the server open (local development only).
+func New(client *claude.Client, auth Auth, maxTokens int32) *Server {
+	s := &Server{
+		client:       client,
+		auth:         auth,
+		defaultModel: client.ModelID,
+		maxTokens:    maxTokens,
+		mux:          http.NewServeMux(),
+		metrics:      newMetrics(),
+		Approvals:    NewApprovals(),
+	}
+	s.mux.HandleFunc("POST /v1/chat", s.withAuth(s.handleChat))
+	s.mux.HandleFunc("GET /v1/usage", s.withAuth(s.handleUsage))
+	// The approvals handlers are tenant-agnostic: the queue is shared, so
+	// auth only gates access.
+	s.mux.HandleFunc("GET /v1/approvals", s.withAuth(func(w http.ResponseWriter, r *http.Request, _ *Tenant) {
+		s.Approvals.handleList(w, r)
+	}))
+	s.mux.HandleFunc("POST /v1/approvals/{id}", s.withAuth(func(w http.ResponseWriter, r *http.Request, _ *Tenant) {
+		s.Approvals.handleDecide(w, r)
+	}))
+	// Probe and metrics endpoints are unauthenticated so load balancers and
+	// scrapers can reach them; they expose no tenant data.
+	s.mux.HandleFunc("GET /healthz", s.handleHealthz)
+	s.mux.HandleFunc("GET /readyz", s.handleReadyz)
+	s.mux.HandleFunc("GET /metrics", s.handleMetrics)
+	return s
+}
+
+// handleHealthz reports liveness: the process is up and serving.
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// handleReadyz reports readiness: Bedrock is reachable with valid
+// credentials. Results are cached briefly so aggressive probe intervals do
+// not turn into AWS API calls.
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	if s.ReadyCheck == nil {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+		return
+	}
+	if err := s.cachedReadyCheck(r.Context()); err != nil {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{
+			"status": "unavailable",
+			"error":  err.Error(),
+		})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// readyCacheTTL is how long a readiness probe result is reused.
+const readyCacheTTL = 15 * time.Second
+
+func (s *Server) cachedReadyCheck(ctx context.Context) error {
+	s.readyMu.Lock()
+	defer s.readyMu.Unlock()
+	if time.Since(s.readyAt) < readyCacheTTL {
+		return s.readyErr
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	s.readyErr = s.ReadyCheck(ctx)
+	s.readyAt = time.Now()
+	return s.readyErr
+}
+
+// handleMetrics serves the Prometheus exposition endpoint.
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	s.metrics.writePrometheus(w)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// withAuth wraps a handler with tenant authentication, rate limiting, and
+// model allow-list enforcement.
+func (s *Server) withAuth(next func(http.ResponseWriter, *http.Request, *Tenant)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var tenant *Tenant
+		if s.auth != nil {
+			var err error
+			tenant, err = s.auth.Authenticate(r)
+			if err != nil {
+				httpError(w, http.StatusUnauthorized, err.Error())
+				return
+			}
+			if tenant.limiter != nil && !tenant.limiter.Allow() {
+				httpError(w, http.StatusTooManyRequests, "rate limit exceeded for tenant "+tenant.Name)
+				return
+			}
+		}
+		next(w, r, tenant)
+	}
+}
+
+// tenantClient returns the assume-role client for a tenant with a
+// cross-account RoleARN, building and caching it on first use. Chained STS
+// sessions renew inside the client as they expire.
+func (s *Server) tenantClient(ctx context.Context, t *Tenant) (*claude.Client, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.client == nil {
+		c, err := claude.NewClientAssumeRole(ctx, s.Region, s.defaultModel, claude.AssumeRole{
+			RoleARN:     t.RoleARN,
+			ExternalID:  t.ExternalID,
+			SessionName: "claude-bedrock-cli-" + t.Name,
+		})
+		if err != nil {
+			return nil, err
+		}
+		t.client = c
+	}
+	return t.client, nil
+}
+
+func (s *Server) handleChat(w http.ResponseWriter, r *http.Request, tenant *Tenant) {
+	maxBody := s.MaxBodyBytes
+	if maxBody <= 0 {
+		maxBody = DefaultMaxBodyBytes
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxBody)
+
+	var req ChatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httpError(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+		return
+	}
+	if len(req.Messages) == 0 {
+		httpError(w, http.StatusBadRequest, "messages must not be empty")
+		return
+	}
+	model := req.Model
+	if model == "" {
+		model = s.defaultModel
+	}
+	if tenant != nil && !tenant.ModelAllowed(model) {
+		httpError(w, http.StatusForbidden, fmt.Sprintf("tenant %s may not use model %s", tenant.Name, model))
+		return
+	}
+
+	creq := claude.Request{System: req.System, MaxTokens: req.MaxTokens}
+	if creq.MaxTokens <= 0 || creq.MaxTokens > s.maxTokens {
+		creq.MaxTokens = s.maxTokens
+	}
+	if tenant != nil && tenant.MaxTokens > 0 && creq.MaxTokens > tenant.MaxTokens {
+		creq.MaxTokens = tenant.MaxTokens
+	}
+	for _, m := range req.Messages {
+		if m.Role == "assistant" {
+			creq.Messages = append(creq.Messages, claude.AssistantMessage(m.Text))
+		} else {
+			creq.Messages = append(creq.Messages, claude.UserMessage(m.Text))
+		}
+	}
+
+	// The shared client is bound to one model; derive a per-request view
+	// that reuses the same connection pool. Tenants with a cross-account role
+	// instead get a client authenticated into their own account.
+	client := s.client.WithModel(model)
+	if tenant != nil && tenant.RoleARN != "" {
+		tc, err := s.tenantClient(r.Context(), tenant)
+		if err != nil {
+			httpError(w, http.StatusInternalServerError, "assuming tenant role: "+err.Error())
+			return
+		}
+		client = tc.WithModel(model)
+	}
+
+	if req.Stream {
+		s.streamChat(w, r, client, creq, tenant)
+		return
+	}
+	start := time.Now()
+	text, usage, err := client.ConverseUsage(r.Context(), creq)
+	s.metrics.observe(model, time.Since(start), usage, err)
+	if err != nil {
+		httpError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	if tenant != nil {
+		tenant.recordUsage(usage)
+	}
+	writeJSON(w, http.StatusOK, ChatResponse{
+		Text:         text,
+		Model:        model,
+		InputTokens:  usage.InputTokens,
+		OutputTokens: usage.OutputTokens,
+	})
+}
+
+// streamChat relays text deltas as Server-Sent Events, ending with a usage
+// event. Deltas pass through a bounded buffer: when the client cannot keep
+// up within SlowClientTimeout, or disconnects, the Bedrock stream is
+// cancelled instead of buffering without limit.
+func (s *Server) streamChat(w http.ResponseWriter, r *http.Request, client *claude.Client, creq claude.Request, tenant *Tenant) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		httpError(w, http.StatusInternalServerError, "streaming unsupported")
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+
+	stallBudget := s.SlowClientTimeout
+	if stallBudget <= 0 {
+		stallBudget = defaultSlowClientTimeout
+	}
+	// r.Context() is cancelled by net/http when the client disconnects,
+	// which tears down the upstream stream as well.
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	deltas := make(chan string, streamBufferDeltas)
+	type streamResult struct {
+		usage claude.Usage
+		err   error
+	}
+	done := make(chan streamResult, 1)
+	start := time.Now()
+	go func() {
+		defer close(deltas)
+		stall := time.NewTimer(stallBudget)
+		defer stall.Stop()
+		push := func(delta string) {
+			if !stall.Stop() {
+				<-stall.C
+			}
+			stall.Reset(stallBudget)
+			select {
+			case deltas <- delta:
+			case <-stall.C:
+				log.Printf("server: cancelling stream for slow client")
+				cancel()
+			case <-ctx.Done():
+			}
+		}
+		onDelta := push
+		if s.CoalesceWindow > 0 {
+			// Batch tiny deltas into larger SSE frames; the remainder is
+			// flushed before the deltas channel closes.
+			co := claude.NewCoalescer(push, 0, s.CoalesceWindow)
+			defer co.Close()
+			onDelta = co.Add
+		}
+		_, usage, err := client.ConverseStreamUsage(ctx, creq, onDelta)
+		done <- streamResult{usage: usage, err: err}
+	}()
+
+	for delta := range deltas {
+		data, _ := json.Marshal(map[string]string{"delta": delta})
+		fmt.Fprintf(w, "data: %s\n\n", data)
+		flusher.Flush()
+	}
+	res := <-done
+	usage, err := res.usage, res.err
+	s.metrics.observe(client.ModelID, time.Since(start), usage, err)
+	if err != nil {
+		data, _ := json.Marshal(map[string]string{"error": err.Error()})
+		fmt.Fprintf(w, "event: error\ndata: %s\n\n", data)
+		flusher.Flush()
+		return
+	}
+	if tenant != nil {
+		tenant.recordUsage(usage)
+	}
+	data, _ := json.Marshal(map[string]int32{
+		"input_tokens":  usage.InputTokens,
+		"output_tokens": usage.OutputTokens,
+	})
+	fmt.Fprintf(w, "event: usage\ndata: %s\n\ndata: [DONE]\n\n", data)
+	flusher.Flush()
+}
+
+func (s *Server) handleUsage(w http.ResponseWriter, r *http.Request, tenant *Tenant) {
+	if tenant == nil {
+		httpError(w, http.StatusBadRequest, "usage accounting requires tenant auth")
+		return
+	}
+	in, out, requests := tenant.usageTotals()
+	writeJSON(w, http.StatusOK, map[string]any{
+		"tenant":        tenant.Name,
+		"requests":      requests,
+		"input_tokens":  in,
+		"output_tokens": out,
+	})
+}
+
+func httpError(w http.ResponseWriter, code int, msg string) {
+	writeJSON(w, code, map[string]string{"error": msg})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("server: writing response: %v", err)
+	}
+}
+
+// DefaultDrainTimeout bounds how long in-flight requests and streams may run
+// after a shutdown signal before the server closes them.
+const DefaultDrainTimeout = 30 * time.Second
+
+// ListenAndServe runs the server until the listener fails or a SIGINT/SIGTERM
+// arrives. On a signal it stops accepting new connections, lets in-flight
+// requests (including active streams) finish within DrainTimeout, then closes
+// — the behaviour ECS and Kubernetes rolling deploys expect.
+func (s *Server) ListenAndServe(addr string) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errc := make(chan error, 1)
+	go func() {
+		log.Printf("server: listening on %s", addr)
+		errc <- srv.ListenAndServe()
+	}()
+
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigc)
+
+	select {
+	case err := <-errc:
+		return err
+	case sig := <-sigc:
+		drain := s.DrainTimeout
+		if drain <= 0 {
+			drain = DefaultDrainTimeout
+		}
+		log.Printf("server: received %s; draining for up to %s", sig, drain)
+		ctx, cancel := context.WithTimeout(context.Background(), drain)
+		defer cancel()
+		if err := srv.Shutdown(ctx); err != nil {
+			log.Printf("server: drain period expired; closing remaining connections")
+			return srv.Close()
+		}
+		log.Printf("server: drained cleanly")
+		return nil
+	}
+}
@@ -0,0 +1,61 @@
+package session
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+func roleTitle(role string) string {
+	if role == "" {
+		return role
+	}
+	return strings.ToUpper(role[:1]) + role[1:]
+}
+
+// ExportMarkdown renders the active branch of the session as a Markdown
+// transcript with per-turn timestamps.
+func ExportMarkdown(s *Session) []byte {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Conversation %s\n\n", s.ID)
+	fmt.Fprintf(&sb, "- Branch: %s\n- Started: %s\n- Updated: %s\n\n",
+		s.Branch, s.Created.Format("2006-01-02 15:04:05"), s.Updated.Format("2006-01-02 15:04:05"))
+	for _, turn := range s.Turns() {
+		fmt.Fprintf(&sb, "## %s — %s\n\n", roleTitle(turn.Role), turn.Time.Format("15:04:05"))
+		sb.WriteString(turn.Text)
+		sb.WriteString("\n\n")
+	}
+	return []byte(sb.String())
+}
+
+// ExportHTML renders the active branch as a standalone HTML document.
+func ExportHTML(s *Session) []byte {
+	var sb strings.Builder
+	sb.WriteString(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Conversation ` + html.EscapeString(s.ID) + `</title>
+<style>
+body { font-family: sans-serif; max-width: 48rem; margin: 2rem auto; padding: 0 1rem; }
+.turn { margin: 1rem 0; padding: 0.75rem 1rem; border-radius: 0.5rem; white-space: pre-wrap; }
+.user { background: #e8f0fe; }
+.assistant { background: #f1f3f4; }
+.meta { color: #5f6368; font-size: 0.8rem; margin-bottom: 0.25rem; }
+</style>
+</head>
+<body>
+`)
+	fmt.Fprintf(&sb, "<h1>Conversation %s</h1>\n", html.EscapeString(s.ID))
+	fmt.Fprintf(&sb, "<p class=\"meta\">Branch %s &middot; started %s</p>\n",
+		html.EscapeString(s.Branch), s.Created.Format("2006-01-02 15:04:05"))
+	for _, turn := range s.Turns() {
+		fmt.Fprintf(&sb, "<div class=\"turn %s\"><div class=\"meta\">%s &middot; %s</div>%s</div>\n",
+			html.EscapeString(turn.Role),
+			html.EscapeString(roleTitle(turn.Role)),
+			turn.Time.Format("15:04:05"),
+			html.EscapeString(turn.Text))
+	}
+	sb.WriteString("</body>\n</html>\n")
+	return []byte(sb.String())
+}
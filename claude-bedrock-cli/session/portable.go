@@ -0,0 +1,168 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// The portable transcript schema is the interchange form for conversations:
+// a flat, versioned JSON document other tooling can consume without knowing
+// about branches or this package's on-disk layout. Converters translate to
+// and from the Anthropic Messages and OpenAI chat formats, so a transcript
+// captured here can be replayed against either API.
+
+// PortableVersion is the current schema version; Import rejects documents
+// from a newer one.
+const PortableVersion = 1
+
+// PortableTurn is one message in a portable transcript.
+type PortableTurn struct {
+	Role string    `json:"role"` // "user" or "assistant"
+	Text string    `json:"text"`
+	Time time.Time `json:"time,omitempty"`
+}
+
+// Portable is a versioned, branch-free transcript. Export flattens the
+// session's active branch into it; System survives round-trips through
+// formats that carry one, even though sessions themselves store none.
+type Portable struct {
+	Version int            `json:"version"`
+	ID      string         `json:"id,omitempty"`
+	System  string         `json:"system,omitempty"`
+	Created time.Time      `json:"created,omitempty"`
+	Turns   []PortableTurn `json:"turns"`
+}
+
+// ExportPortable flattens the session's active branch into the portable
+// schema.
+func ExportPortable(s *Session) *Portable {
+	p := &Portable{
+		Version: PortableVersion,
+		ID:      s.ID,
+		Created: s.Created,
+	}
+	for _, turn := range s.Turns() {
+		p.Turns = append(p.Turns, PortableTurn{Role: turn.Role, Text: turn.Text, Time: turn.Time})
+	}
+	return p
+}
+
+// ImportPortable builds a fresh session (new ID, single main branch) from a
+// portable transcript. The System field has no home in a session and is
+// dropped; pass it to chat's -system flag when replaying.
+func ImportPortable(p *Portable) (*Session, error) {
+	if p.Version > PortableVersion {
+		return nil, fmt.Errorf("portable transcript version %d is newer than this build supports (%d)", p.Version, PortableVersion)
+	}
+	s := New()
+	for _, turn := range p.Turns {
+		if turn.Role != "user" && turn.Role != "assistant" {
+			return nil, fmt.Errorf("portable transcript: unsupported role %q", turn.Role)
+		}
+		t := Turn{Role: turn.Role, Text: turn.Text, Time: turn.Time}
+		if t.Time.IsZero() {
+			t.Time = time.Now()
+		}
+		s.Branches[s.Branch] = append(s.Branches[s.Branch], t)
+	}
+	return s, nil
+}
+
+// anthropicDocument mirrors the Anthropic Messages request shape: a system
+// string and messages whose content is a list of text blocks. ParseAnthropic
+// also accepts plain-string content, which the API allows as shorthand.
+type anthropicDocument struct {
+	System   string             `json:"system,omitempty"`
+	Messages []anthropicMessage `json:"messages"`
+}
+
+type anthropicMessage struct {
+	Role    string          `json:"role"`
+	Content json.RawMessage `json:"content"`
+}
+
+type anthropicBlock struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// MarshalAnthropic renders the transcript in the Anthropic Messages format.
+func (p *Portable) MarshalAnthropic() ([]byte, error) {
+	doc := anthropicDocument{System: p.System}
+	for _, turn := range p.Turns {
+		content, err := json.Marshal([]anthropicBlock{{Type: "text", Text: turn.Text}})
+		if err != nil {
+			return nil, err
+		}
+		doc.Messages = append(doc.Messages, anthropicMessage{Role: turn.Role, Content: content})
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// ParseAnthropic converts an Anthropic Messages document into the portable
+// schema, concatenating each message's text blocks.
+func ParseAnthropic(data []byte) (*Portable, error) {
+	var doc anthropicDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("anthropic transcript: %w", err)
+	}
+	p := &Portable{Version: PortableVersion, System: doc.System}
+	for i, msg := range doc.Messages {
+		text := ""
+		var blocks []anthropicBlock
+		if err := json.Unmarshal(msg.Content, &blocks); err == nil {
+			for _, block := range blocks {
+				if block.Type == "text" {
+					text += block.Text
+				}
+			}
+		} else if err := json.Unmarshal(msg.Content, &text); err != nil {
+			return nil, fmt.Errorf("anthropic transcript: message %d content: %w", i, err)
+		}
+		p.Turns = append(p.Turns, PortableTurn{Role: msg.Role, Text: text})
+	}
+	return p, nil
+}
+
+// openAIDocument mirrors the OpenAI chat format: a flat message list with a
+// system message inline.
+type openAIDocument struct {
+	Messages []openAIMessage `json:"messages"`
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// MarshalOpenAI renders the transcript in the OpenAI chat format, with the
+// system prompt (when present) as the leading system message.
+func (p *Portable) MarshalOpenAI() ([]byte, error) {
+	var doc openAIDocument
+	if p.System != "" {
+		doc.Messages = append(doc.Messages, openAIMessage{Role: "system", Content: p.System})
+	}
+	for _, turn := range p.Turns {
+		doc.Messages = append(doc.Messages, openAIMessage{Role: turn.Role, Content: turn.Text})
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// ParseOpenAI converts an OpenAI chat document into the portable schema,
+// lifting a leading system message into the System field.
+func ParseOpenAI(data []byte) (*Portable, error) {
+	var doc openAIDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("openai transcript: %w", err)
+	}
+	p := &Portable{Version: PortableVersion}
+	for _, msg := range doc.Messages {
+		if msg.Role == "system" {
+			p.System = msg.Content
+			continue
+		}
+		p.Turns = append(p.Turns, PortableTurn{Role: msg.Role, Text: msg.Content})
+	}
+	return p, nil
+}
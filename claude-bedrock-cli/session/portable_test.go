@@ -0,0 +1,126 @@
+package session
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func sampleSession() *Session {
+	s := New()
+	s.Append("user", "What is envelope encryption?")
+	s.Append("assistant", "Sealing data with a fresh key, then wrapping that key.")
+	return s
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	s := sampleSession()
+	p := ExportPortable(s)
+	if p.Version != PortableVersion {
+		t.Errorf("Version = %d, want %d", p.Version, PortableVersion)
+	}
+	got, err := ImportPortable(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := s.Turns()
+	turns := got.Turns()
+	if len(turns) != len(want) {
+		t.Fatalf("imported %d turns, want %d", len(turns), len(want))
+	}
+	for i := range want {
+		if turns[i].Role != want[i].Role || turns[i].Text != want[i].Text {
+			t.Errorf("turn %d = %+v, want %+v", i, turns[i], want[i])
+		}
+	}
+}
+
+func TestImportRejectsNewerVersion(t *testing.T) {
+	if _, err := ImportPortable(&Portable{Version: PortableVersion + 1}); err == nil {
+		t.Fatal("accepted a transcript from a newer schema version")
+	}
+}
+
+func TestImportRejectsUnknownRole(t *testing.T) {
+	p := &Portable{Version: PortableVersion, Turns: []PortableTurn{{Role: "tool", Text: "x"}}}
+	if _, err := ImportPortable(p); err == nil {
+		t.Fatal("accepted an unsupported role")
+	}
+}
+
+func TestAnthropicRoundTrip(t *testing.T) {
+	p := ExportPortable(sampleSession())
+	p.System = "Be brief."
+	data, err := p.MarshalAnthropic()
+	if err != nil {
+		t.Fatal(err)
+	}
+	back, err := ParseAnthropic(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.System != "Be brief." {
+		t.Errorf("System = %q after round trip", back.System)
+	}
+	if len(back.Turns) != len(p.Turns) {
+		t.Fatalf("round trip has %d turns, want %d", len(back.Turns), len(p.Turns))
+	}
+	for i := range p.Turns {
+		if back.Turns[i].Role != p.Turns[i].Role || back.Turns[i].Text != p.Turns[i].Text {
+			t.Errorf("turn %d = %+v, want %+v", i, back.Turns[i], p.Turns[i])
+		}
+	}
+}
+
+func TestParseAnthropicAcceptsStringContent(t *testing.T) {
+	doc := `{"messages":[{"role":"user","content":"plain shorthand"}]}`
+	p, err := ParseAnthropic([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Turns) != 1 || p.Turns[0].Text != "plain shorthand" {
+		t.Fatalf("Turns = %+v", p.Turns)
+	}
+}
+
+func TestParseAnthropicConcatenatesTextBlocks(t *testing.T) {
+	doc := `{"messages":[{"role":"assistant","content":[{"type":"text","text":"a"},{"type":"text","text":"b"}]}]}`
+	p, err := ParseAnthropic([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Turns[0].Text != "ab" {
+		t.Fatalf("Text = %q, want %q", p.Turns[0].Text, "ab")
+	}
+}
+
+func TestOpenAIRoundTrip(t *testing.T) {
+	p := ExportPortable(sampleSession())
+	p.System = "Be brief."
+	data, err := p.MarshalOpenAI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The system prompt must ride as a leading system message.
+	var doc struct {
+		Messages []struct {
+			Role    string `json:"role"`
+			Content string `json:"content"`
+		} `json:"messages"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Messages[0].Role != "system" || doc.Messages[0].Content != "Be brief." {
+		t.Fatalf("leading message = %+v, want the system prompt", doc.Messages[0])
+	}
+	back, err := ParseOpenAI(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back.System != "Be brief." {
+		t.Errorf("System = %q after round trip", back.System)
+	}
+	if len(back.Turns) != len(p.Turns) {
+		t.Fatalf("round trip has %d turns, want %d", len(back.Turns), len(p.Turns))
+	}
+}
@@ -0,0 +1,357 @@
+// Package session persists interactive conversations, including branches
+// forked from earlier turns.
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBranch is the branch every new session starts on.
+const DefaultBranch = "main"
+
+// Turn is a single message in a conversation.
+type Turn struct {
+	Role string    `json:"role"` // "user" or "assistant"
+	Text string    `json:"text"`
+	Time time.Time `json:"time"`
+}
+
+// Session is a persisted conversation. All history lives in named branches;
+// Branch selects the one the conversation is currently on. Methods are safe
+// for concurrent use, so one session can back several simultaneous streams.
+type Session struct {
+	ID       string            `json:"id"`
+	Branch   string            `json:"branch"`
+	Branches map[string][]Turn `json:"branches"`
+	Created  time.Time         `json:"created"`
+	Updated  time.Time         `json:"updated"`
+
+	// Title and Tags are a generated summary of what the conversation is
+	// about; chat fills them in on exit so lists and search stay readable.
+	Title string   `json:"title,omitempty"`
+	Tags  []string `json:"tags,omitempty"`
+
+	mu sync.Mutex
+}
+
+// New returns an empty session with a timestamp-derived ID.
+func New() *Session {
+	now := time.Now()
+	return &Session{
+		ID:       now.Format("20060102-150405"),
+		Branch:   DefaultBranch,
+		Branches: map[string][]Turn{DefaultBranch: nil},
+		Created:  now,
+		Updated:  now,
+	}
+}
+
+// Turns returns a snapshot of the active branch's history; concurrent
+// Appends do not mutate it.
+func (s *Session) Turns() []Turn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Turn(nil), s.Branches[s.Branch]...)
+}
+
+// Append adds a turn to the active branch.
+func (s *Session) Append(role, text string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Branches[s.Branch] = append(s.Branches[s.Branch], Turn{
+		Role: role,
+		Text: text,
+		Time: time.Now(),
+	})
+	s.Updated = time.Now()
+}
+
+// Fork snapshots the active branch up to and including turn index upTo (-1
+// for the whole history) into a new branch and switches to it.
+func (s *Session) Fork(name string, upTo int) error {
+	if name == "" {
+		return fmt.Errorf("fork: branch name required")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.Branches[name]; exists {
+		return fmt.Errorf("fork: branch %q already exists", name)
+	}
+	src := s.Branches[s.Branch]
+	if upTo < 0 || upTo >= len(src) {
+		upTo = len(src) - 1
+	}
+	s.Branches[name] = append([]Turn(nil), src[:upTo+1]...)
+	s.Branch = name
+	s.Updated = time.Now()
+	return nil
+}
+
+// Switch makes an existing branch the active one.
+func (s *Session) Switch(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.Branches[name]; !ok {
+		return fmt.Errorf("switch: no branch %q (have: %s)", name, strings.Join(s.branchNames(), ", "))
+	}
+	s.Branch = name
+	s.Updated = time.Now()
+	return nil
+}
+
+// BranchNames lists branch names sorted alphabetically.
+func (s *Session) BranchNames() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.branchNames()
+}
+
+func (s *Session) branchNames() []string {
+	names := make([]string, 0, len(s.Branches))
+	for name := range s.Branches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Cipher encrypts session files at rest; the kmscrypt package provides a
+// KMS envelope implementation.
+type Cipher interface {
+	Encrypt(ctx context.Context, plaintext []byte) ([]byte, error)
+	Decrypt(ctx context.Context, data []byte) ([]byte, error)
+}
+
+// Store reads and writes sessions as JSON files in a directory.
+type Store struct {
+	dir string
+
+	// Cipher, when set, encrypts session files on Save and decrypts them on
+	// Load, so transcripts with sensitive content are never plaintext on
+	// disk.
+	Cipher Cipher
+
+	// Redact, when set, is applied to every turn's text on Save, so the file
+	// on disk never contains content the policy forbids. The in-memory
+	// session is untouched; redaction only follows content to storage.
+	Redact func(ctx context.Context, text string) (string, error)
+}
+
+// OpenStore creates (if needed) and returns the session store. When dir is
+// empty, ~/.claude-bedrock-cli/sessions is used.
+func OpenStore(dir string) (*Store, error) {
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Join(home, ".claude-bedrock-cli", "sessions")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &Store{dir: dir}, nil
+}
+
+func (st *Store) path(id string) string {
+	return filepath.Join(st.dir, id+".json")
+}
+
+// Save writes the session to disk, encrypted when a Cipher is configured.
+// ctx bounds the cipher's KMS calls. The file is written to a temporary name
+// and renamed into place, so concurrent saves of one session cannot leave a
+// torn file.
+func (st *Store) Save(ctx context.Context, s *Session) error {
+	var data []byte
+	var err error
+	if st.Redact == nil {
+		s.mu.Lock()
+		data, err = json.MarshalIndent(s, "", "  ")
+		s.mu.Unlock()
+	} else {
+		// Redaction snapshots the session so the scrubbed copy goes to disk
+		// while the live conversation keeps its original text.
+		s.mu.Lock()
+		out := &Session{
+			ID:       s.ID,
+			Branch:   s.Branch,
+			Branches: make(map[string][]Turn, len(s.Branches)),
+			Created:  s.Created,
+			Updated:  s.Updated,
+			Title:    s.Title,
+			Tags:     append([]string(nil), s.Tags...),
+		}
+		for name, turns := range s.Branches {
+			out.Branches[name] = append([]Turn(nil), turns...)
+		}
+		s.mu.Unlock()
+		for _, turns := range out.Branches {
+			for i := range turns {
+				if turns[i].Text, err = st.Redact(ctx, turns[i].Text); err != nil {
+					return fmt.Errorf("session %s: %w", s.ID, err)
+				}
+			}
+		}
+		data, err = json.MarshalIndent(out, "", "  ")
+	}
+	if err != nil {
+		return err
+	}
+	if st.Cipher != nil {
+		if data, err = st.Cipher.Encrypt(ctx, data); err != nil {
+			return fmt.Errorf("session %s: %w", s.ID, err)
+		}
+	}
+	tmp := st.path(s.ID) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, st.path(s.ID))
+}
+
+// Load reads a session by ID. ctx bounds the cipher's KMS calls.
+func (st *Store) Load(ctx context.Context, id string) (*Session, error) {
+	data, err := os.ReadFile(st.path(id))
+	if err != nil {
+		return nil, err
+	}
+	if st.Cipher != nil {
+		if data, err = st.Cipher.Decrypt(ctx, data); err != nil {
+			return nil, fmt.Errorf("session %s: %w", id, err)
+		}
+	} else if strings.Contains(string(data), `"encrypted_key"`) {
+		return nil, fmt.Errorf("session %s is encrypted; pass the KMS key it was saved with", id)
+	}
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("session %s: %w", id, err)
+	}
+	if s.Branch == "" {
+		s.Branch = DefaultBranch
+	}
+	if s.Branches == nil {
+		s.Branches = map[string][]Turn{s.Branch: nil}
+	}
+	return &s, nil
+}
+
+// Delete removes a stored session by ID.
+func (st *Store) Delete(id string) error {
+	if err := os.Remove(st.path(id)); err != nil {
+		return fmt.Errorf("session %s: %w", id, err)
+	}
+	return nil
+}
+
+// Retention is a purge policy for stored sessions. Zero fields do not
+// constrain that dimension.
+type Retention struct {
+	MaxAge      time.Duration // drop sessions not updated within this window
+	MaxSessions int           // keep at most this many, newest first
+}
+
+// Purge deletes sessions violating the retention policy and returns the IDs
+// removed. Age is judged by file modification time, which tracks the last
+// Save rather than the ID's creation timestamp.
+func (st *Store) Purge(policy Retention) ([]string, error) {
+	ids, err := st.List()
+	if err != nil {
+		return nil, err
+	}
+	var removed []string
+	if policy.MaxAge > 0 {
+		cutoff := time.Now().Add(-policy.MaxAge)
+		kept := ids[:0]
+		for _, id := range ids {
+			info, err := os.Stat(st.path(id))
+			if err != nil {
+				return removed, err
+			}
+			if info.ModTime().Before(cutoff) {
+				if err := st.Delete(id); err != nil {
+					return removed, err
+				}
+				removed = append(removed, id)
+			} else {
+				kept = append(kept, id)
+			}
+		}
+		ids = kept
+	}
+	if policy.MaxSessions > 0 && len(ids) > policy.MaxSessions {
+		// List is newest first; everything past the cap is oldest.
+		for _, id := range ids[policy.MaxSessions:] {
+			if err := st.Delete(id); err != nil {
+				return removed, err
+			}
+			removed = append(removed, id)
+		}
+	}
+	return removed, nil
+}
+
+// TurnMatch is one search hit: the turn plus enough context to reopen the
+// conversation it came from.
+type TurnMatch struct {
+	SessionID string
+	Branch    string
+	// Index is the turn's position on its branch.
+	Index int
+	Turn  Turn
+}
+
+// Search scans every stored session for turns containing the query,
+// case-insensitively, newest session first. Sessions that cannot be loaded
+// (for example encrypted ones when no Cipher is configured) are skipped
+// rather than failing the whole search.
+func (st *Store) Search(ctx context.Context, query string) ([]TurnMatch, error) {
+	ids, err := st.List()
+	if err != nil {
+		return nil, err
+	}
+	query = strings.ToLower(query)
+	var matches []TurnMatch
+	for _, id := range ids {
+		s, err := st.Load(ctx, id)
+		if err != nil {
+			continue
+		}
+		for _, branch := range s.branchNames() {
+			for i, turn := range s.Branches[branch] {
+				if strings.Contains(strings.ToLower(turn.Text), query) {
+					matches = append(matches, TurnMatch{
+						SessionID: id,
+						Branch:    branch,
+						Index:     i,
+						Turn:      turn,
+					})
+				}
+			}
+		}
+	}
+	return matches, nil
+}
+
+// List returns the IDs of all stored sessions, newest first.
+func (st *Store) List() ([]string, error) {
+	entries, err := os.ReadDir(st.dir)
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, e := range entries {
+		if name, ok := strings.CutSuffix(e.Name(), ".json"); ok && !e.IsDir() {
+			ids = append(ids, name)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(ids)))
+	return ids, nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/session"
+)
+
+// titleModelID is the cheap model that summarizes sessions into a title and
+// tags; the spend is a few hundred tokens per conversation.
+const titleModelID = "anthropic.claude-3-haiku-20240307-v1:0"
+
+// titleSystem asks for machine-parseable output only; the JSON shape is
+// decoded by autoTitleSession below.
+const titleSystem = `You label conversation transcripts. Reply with only a JSON object of the form {"title": "...", "tags": ["...", "..."]}: a title of at most eight words and one to four lowercase topic tags. No other text.`
+
+// titleTranscriptCap bounds how much of the transcript is sent for labeling;
+// the opening turns carry the topic.
+const titleTranscriptCap = 6000
+
+// autoTitleSession generates a title and topic tags for the session with a
+// cheap model call and stores them on it. It is best effort: it does nothing
+// when the session already has a title, has no turns, or when
+// CLAUDE_CLI_NO_AUTOTITLE=1, and a failed call only logs a warning so exiting
+// chat never fails on a labeling problem.
+func autoTitleSession(ctx context.Context, client *claude.Client, sess *session.Session) {
+	if sess.Title != "" || os.Getenv("CLAUDE_CLI_NO_AUTOTITLE") == "1" {
+		return
+	}
+	turns := sess.Turns()
+	if len(turns) == 0 {
+		return
+	}
+	var b strings.Builder
+	for _, turn := range turns {
+		b.WriteString(turn.Role)
+		b.WriteString(": ")
+		b.WriteString(turn.Text)
+		b.WriteString("\n")
+		if b.Len() > titleTranscriptCap {
+			break
+		}
+	}
+	reply, err := client.WithModel(titleModelID).Converse(ctx, claude.Request{
+		System:    titleSystem,
+		Messages:  []claude.Message{claude.UserMessage(b.String())},
+		MaxTokens: 256,
+	})
+	if err != nil {
+		log.Printf("session title: %v", err)
+		return
+	}
+	var meta struct {
+		Title string   `json:"title"`
+		Tags  []string `json:"tags"`
+	}
+	if err := json.Unmarshal([]byte(extractJSON(reply)), &meta); err != nil {
+		log.Printf("session title: unparseable reply: %v", err)
+		return
+	}
+	sess.Title = strings.TrimSpace(meta.Title)
+	sess.Tags = meta.Tags
+	if sess.Title != "" {
+		note("session titled %q", sess.Title)
+	}
+}
+
+// extractJSON trims any prose the model wrapped around the JSON object.
+func extractJSON(s string) string {
+	start := strings.Index(s, "{")
+	end := strings.LastIndex(s, "}")
+	if start < 0 || end <= start {
+		return s
+	}
+	return s[start : end+1]
+}
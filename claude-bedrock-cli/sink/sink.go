@@ -0,0 +1,164 @@
+// Package sink delivers job outputs to external destinations: an SNS
+// topic, a Slack incoming webhook, or SES email. Sinks are configured by
+// spec strings so schedule definitions can name them directly.
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	sestypes "github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+)
+
+// Sink delivers one message.
+type Sink interface {
+	Deliver(ctx context.Context, subject, body string) error
+}
+
+// New builds a sink from a spec string:
+//
+//	sns:<topic-arn>
+//	slack:<webhook-url>
+//	ses:<from>,<to>[,<to>...]
+//
+// An empty spec delivers to stdout.
+func New(ctx context.Context, region, spec string) (Sink, error) {
+	if spec == "" {
+		return Stdout{}, nil
+	}
+	kind, rest, ok := strings.Cut(spec, ":")
+	if !ok {
+		return nil, fmt.Errorf("sink: malformed spec %q", spec)
+	}
+	switch kind {
+	case "sns":
+		cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+		if err != nil {
+			return nil, err
+		}
+		return &SNS{client: sns.NewFromConfig(cfg), topicARN: rest}, nil
+	case "slack":
+		if !strings.HasPrefix(rest, "https://") {
+			return nil, fmt.Errorf("sink: slack webhook must be an https URL, got %q", rest)
+		}
+		return &Slack{URL: rest}, nil
+	case "ses":
+		parts := strings.Split(rest, ",")
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("sink: ses spec needs <from>,<to>[,<to>...], got %q", rest)
+		}
+		cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+		if err != nil {
+			return nil, err
+		}
+		return &SES{client: sesv2.NewFromConfig(cfg), from: parts[0], to: parts[1:]}, nil
+	default:
+		return nil, fmt.Errorf("sink: unknown kind %q (have sns, slack, ses)", kind)
+	}
+}
+
+// Render substitutes {{key}} placeholders in a subject or body template.
+func Render(tmpl string, vars map[string]string) string {
+	for key, value := range vars {
+		tmpl = strings.ReplaceAll(tmpl, "{{"+key+"}}", value)
+	}
+	return tmpl
+}
+
+// Stdout prints the message, for local runs and testing.
+type Stdout struct{}
+
+func (Stdout) Deliver(ctx context.Context, subject, body string) error {
+	fmt.Printf("=== %s ===\n%s\n", subject, body)
+	return nil
+}
+
+// SNS publishes to a topic.
+type SNS struct {
+	client   *sns.Client
+	topicARN string
+}
+
+func (s *SNS) Deliver(ctx context.Context, subject, body string) error {
+	// SNS caps subjects at 100 characters.
+	if len(subject) > 100 {
+		subject = subject[:100]
+	}
+	_, err := s.client.Publish(ctx, &sns.PublishInput{
+		TopicArn: aws.String(s.topicARN),
+		Subject:  aws.String(subject),
+		Message:  aws.String(body),
+	})
+	if err != nil {
+		return fmt.Errorf("sns publish: %w", err)
+	}
+	return nil
+}
+
+// Slack posts to an incoming webhook.
+type Slack struct {
+	URL string
+	// HTTPClient overrides http.DefaultClient when set.
+	HTTPClient *http.Client
+}
+
+func (s *Slack) Deliver(ctx context.Context, subject, body string) error {
+	payload, err := json.Marshal(map[string]string{
+		"text": "*" + subject + "*\n" + body,
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("slack webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("slack webhook: status %s", resp.Status)
+	}
+	return nil
+}
+
+// SES sends plain-text email.
+type SES struct {
+	client *sesv2.Client
+	from   string
+	to     []string
+}
+
+func (s *SES) Deliver(ctx context.Context, subject, body string) error {
+	_, err := s.client.SendEmail(ctx, &sesv2.SendEmailInput{
+		FromEmailAddress: aws.String(s.from),
+		Destination:      &sestypes.Destination{ToAddresses: s.to},
+		Content: &sestypes.EmailContent{
+			Simple: &sestypes.Message{
+				Subject: &sestypes.Content{Data: aws.String(subject)},
+				Body: &sestypes.Body{
+					Text: &sestypes.Content{Data: aws.String(body)},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("ses send: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// summaryCache persists per-chunk summaries keyed by a content hash, so
+// re-summarizing an edited document only pays for the chunks that changed.
+type summaryCache struct {
+	dir string
+}
+
+// openSummaryCache creates (if needed) and returns the on-disk chunk-summary
+// cache. When dir is empty the user cache directory is used.
+func openSummaryCache(dir string) (*summaryCache, error) {
+	if dir == "" {
+		base, err := os.UserCacheDir()
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Join(base, "claude-bedrock-cli", "summaries")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &summaryCache{dir: dir}, nil
+}
+
+// key hashes the chunk content together with the system prompt, so changing
+// the summary style invalidates cached entries as well.
+func (c *summaryCache) key(system, text string) string {
+	h := sha256.New()
+	h.Write([]byte(system))
+	h.Write([]byte{0})
+	h.Write([]byte(text))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// get returns the cached summary for the chunk, or ok=false on a miss.
+func (c *summaryCache) get(system, text string) (summary string, ok bool) {
+	data, err := os.ReadFile(filepath.Join(c.dir, c.key(system, text)))
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// put stores the summary for the chunk; cache write failures are returned so
+// callers can decide whether to warn or ignore.
+func (c *summaryCache) put(system, text, summary string) error {
+	return os.WriteFile(filepath.Join(c.dir, c.key(system, text)), []byte(summary), 0o644)
+}
+
+// clear removes every cached summary.
+func (c *summaryCache) clear() error {
+	err := filepath.WalkDir(c.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		return os.Remove(path)
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
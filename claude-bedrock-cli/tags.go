@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// tagFlags collects repeatable -tag key=value pairs for cost allocation.
+type tagFlags map[string]string
+
+func (t *tagFlags) String() string { return formatTags(*t) }
+
+func (t *tagFlags) Set(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("tag must be key=value, got %q", value)
+	}
+	if *t == nil {
+		*t = tagFlags{}
+	}
+	(*t)[key] = val
+	return nil
+}
+
+// invocationTags holds the tags applied to every invocation this process
+// makes; set from -tag flags and the CLAUDE_CLI_TAGS environment variable
+// (comma-separated key=value pairs), with flags winning on conflict.
+var invocationTags map[string]string
+
+// invocationTier records the service tier requested for this process's
+// invocations so audit cost estimates reflect the tier's pricing.
+var invocationTier string
+
+// applyTags merges environment tags under the flag-provided ones and
+// installs the result for audit records and request metadata.
+func applyTags(flags tagFlags) map[string]string {
+	merged := map[string]string{}
+	for _, pair := range strings.Split(os.Getenv("CLAUDE_CLI_TAGS"), ",") {
+		if key, val, ok := strings.Cut(pair, "="); ok && key != "" {
+			merged[key] = val
+		}
+	}
+	for key, val := range flags {
+		merged[key] = val
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+	invocationTags = merged
+	return merged
+}
+
+// formatTags renders tags deterministically as "k=v,k=v".
+func formatTags(tags map[string]string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+"="+tags[key])
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,251 @@
+// Package tui renders a streaming invocation as a full-screen terminal UI:
+// the live answer, a collapsible reasoning pane, tool activity, and a status
+// bar with token and cost accounting. It draws plain ANSI sequences on the
+// alternate screen and consumes the typed event stream from the claude
+// package, so no TUI framework is involved.
+package tui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/chzyer/readline"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+)
+
+// UI streams one request full screen. Keys: t collapses or expands the
+// reasoning pane, q or Ctrl-C cancels the invocation.
+type UI struct {
+	Client *claude.Client
+	// CostTier prices the status bar's running cost estimate; empty is
+	// standard on-demand.
+	CostTier string
+}
+
+// state is everything a frame renders from.
+type state struct {
+	answer       strings.Builder
+	thinking     strings.Builder
+	tools        []string
+	usage        claude.Usage
+	showThinking bool
+	stop         string
+	start        time.Time
+}
+
+// Run drives the invocation and returns the assembled answer once the
+// screen is restored, so the caller can print or post-process it normally.
+func (u *UI) Run(ctx context.Context, req claude.Request) (string, claude.Usage, error) {
+	fd := int(os.Stdin.Fd())
+	if !readline.IsTerminal(fd) {
+		return "", claude.Usage{}, fmt.Errorf("tui: stdin is not a terminal")
+	}
+	old, err := readline.MakeRaw(fd)
+	if err != nil {
+		return "", claude.Usage{}, fmt.Errorf("tui: %w", err)
+	}
+	defer readline.Restore(fd, old)
+	fmt.Print("\x1b[?1049h\x1b[?25l")
+	defer fmt.Print("\x1b[?25h\x1b[?1049l")
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// The key reader stays blocked on stdin when Run returns; the process
+	// is about to exit, so the goroutine is not reclaimed.
+	keys := make(chan byte, 8)
+	go func() {
+		buf := make([]byte, 1)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if err != nil {
+				return
+			}
+			if n > 0 {
+				keys <- buf[0]
+			}
+		}
+	}()
+
+	events, err := u.Client.StreamEvents(ctx, req)
+	if err != nil {
+		return "", claude.Usage{}, err
+	}
+
+	s := &state{showThinking: true, start: time.Now()}
+	ticker := time.NewTicker(80 * time.Millisecond)
+	defer ticker.Stop()
+	var streamErr error
+	for events != nil {
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				events = nil
+				break
+			}
+			switch ev := ev.(type) {
+			case claude.TextDelta:
+				s.answer.WriteString(ev.Text)
+			case claude.ReasoningDelta:
+				s.thinking.WriteString(ev.Text)
+			case claude.ToolCall:
+				s.tools = append(s.tools, ev.Name+" "+oneLine(ev.InputJSON, 60))
+			case claude.UsageEvent:
+				s.usage = ev.Usage
+			case claude.Stop:
+				s.stop = string(ev.Reason)
+			case claude.StreamError:
+				streamErr = ev.Err
+			}
+		case key := <-keys:
+			switch key {
+			case 't':
+				s.showThinking = !s.showThinking
+			case 'q', 3: // Ctrl-C
+				cancel()
+			}
+			u.draw(s, fd)
+		case <-ticker.C:
+			u.draw(s, fd)
+		}
+	}
+	u.draw(s, fd)
+	return s.answer.String(), s.usage, streamErr
+}
+
+// draw repaints the whole frame: answer on top, then the reasoning and tool
+// panes when they have content, and the status bar on the bottom row.
+// Repainting from the home position with per-line erases avoids the flicker
+// a full clear would cause.
+func (u *UI) draw(s *state, fd int) {
+	width, height, err := readline.GetSize(fd)
+	if err != nil || width <= 0 || height <= 2 {
+		return
+	}
+	body := height - 1
+
+	thinkingLines := wrap(s.thinking.String(), width)
+	thinkRows := 0
+	if s.showThinking && len(thinkingLines) > 0 {
+		thinkRows = body / 3
+	}
+	toolRows := 0
+	if len(s.tools) > 0 {
+		toolRows = len(s.tools) + 1
+		if max := body / 4; toolRows > max {
+			toolRows = max
+		}
+	}
+	answerRows := body - thinkRows - toolRows
+
+	var rows []string
+	rows = append(rows, pad(tail(wrap(s.answer.String(), width), answerRows), answerRows)...)
+	if thinkRows > 0 {
+		rows = append(rows, header("thinking (t to hide)", width))
+		rows = append(rows, dim(pad(tail(thinkingLines, thinkRows-1), thinkRows-1))...)
+	}
+	if toolRows > 0 {
+		rows = append(rows, header("tools", width))
+		rows = append(rows, pad(tail(s.tools, toolRows-1), toolRows-1)...)
+	}
+	rows = append(rows, u.statusBar(s, width))
+
+	var frame strings.Builder
+	frame.WriteString("\x1b[H")
+	for i, row := range rows {
+		if i > 0 {
+			frame.WriteString("\r\n")
+		}
+		frame.WriteString(row)
+		frame.WriteString("\x1b[K")
+	}
+	frame.WriteString("\x1b[J")
+	os.Stdout.WriteString(frame.String())
+}
+
+// statusBar renders the inverse-video bottom row: model, token counts, the
+// running cost estimate, elapsed time, and the key hints.
+func (u *UI) statusBar(s *state, width int) string {
+	status := fmt.Sprintf(" %s  %d→%d tok", u.Client.ModelID, s.usage.InputTokens, s.usage.OutputTokens)
+	if cost, known := claude.EstimateCostTier(u.Client.ModelID, s.usage, u.CostTier); known {
+		status += fmt.Sprintf("  $%.4f", cost)
+	}
+	status += fmt.Sprintf("  %s", time.Since(s.start).Round(time.Second))
+	if s.stop != "" {
+		status += "  " + s.stop
+	}
+	hint := "t: thinking  q: quit "
+	if gap := width - len(status) - len(hint); gap > 0 {
+		status += strings.Repeat(" ", gap) + hint
+	}
+	if len(status) > width {
+		status = status[:width]
+	}
+	return "\x1b[7m" + status + "\x1b[0m"
+}
+
+// header renders a pane divider like "── title ──...".
+func header(title string, width int) string {
+	line := "── " + title + " "
+	if n := width - len([]rune(line)); n > 0 {
+		line += strings.Repeat("─", n)
+	}
+	return line
+}
+
+// wrap splits text into display lines of at most width runes, breaking on
+// the source's own newlines first.
+func wrap(text string, width int) []string {
+	if text == "" {
+		return nil
+	}
+	var lines []string
+	for _, src := range strings.Split(text, "\n") {
+		runes := []rune(src)
+		for len(runes) > width {
+			lines = append(lines, string(runes[:width]))
+			runes = runes[width:]
+		}
+		lines = append(lines, string(runes))
+	}
+	return lines
+}
+
+// tail keeps the last n lines, the part of a growing pane worth showing.
+func tail(lines []string, n int) []string {
+	if len(lines) > n {
+		return lines[len(lines)-n:]
+	}
+	return lines
+}
+
+// pad extends lines with blanks to exactly n rows so panes keep their place.
+func pad(lines []string, n int) []string {
+	for len(lines) < n {
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+// dim wraps each line in the faint attribute, visually separating reasoning
+// from the answer.
+func dim(lines []string) []string {
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		out[i] = "\x1b[2m" + line + "\x1b[0m"
+	}
+	return out
+}
+
+// oneLine compacts s to a single line of at most n runes for pane rows.
+func oneLine(s string, n int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if runes := []rune(s); len(runes) > n {
+		s = string(runes[:n-1]) + "…"
+	}
+	return s
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/aws-samples/anthropic-on-aws/claude-bedrock-cli/claude"
+)
+
+// loadVideo resolves a -video value into a Converse attachment. An s3:// URI
+// passes through for the service to read directly; a local file travels
+// inline when it fits the request payload, and is otherwise uploaded to the
+// CLAUDE_CLI_VIDEO_BUCKET staging bucket first.
+func loadVideo(ctx context.Context, region, spec string) (claude.Video, error) {
+	format, err := claude.VideoFormatForPath(spec)
+	if err != nil {
+		return claude.Video{}, fmt.Errorf("run: %w", err)
+	}
+	if strings.HasPrefix(spec, "s3://") {
+		return claude.Video{Format: format, S3URI: spec}, nil
+	}
+
+	data, err := os.ReadFile(spec)
+	if err != nil {
+		return claude.Video{}, err
+	}
+	if len(data) > claude.MaxVideoBytes {
+		return claude.Video{}, fmt.Errorf("run: video %s is %d MiB; Bedrock accepts at most %d MiB", spec, len(data)>>20, claude.MaxVideoBytes>>20)
+	}
+	if len(data) <= claude.MaxInlineVideoBytes {
+		return claude.Video{Format: format, Data: data}, nil
+	}
+
+	bucket := os.Getenv("CLAUDE_CLI_VIDEO_BUCKET")
+	if bucket == "" {
+		return claude.Video{}, fmt.Errorf("run: video %s exceeds the %d MiB inline limit; set CLAUDE_CLI_VIDEO_BUCKET to stage large videos through S3", spec, claude.MaxInlineVideoBytes>>20)
+	}
+	uri, err := uploadVideo(ctx, region, bucket, spec, data)
+	if err != nil {
+		return claude.Video{}, err
+	}
+	return claude.Video{Format: format, S3URI: uri}, nil
+}
+
+// uploadVideo stages the video in the bucket under a content-derived key, so
+// re-running with the same file reuses the object.
+func uploadVideo(ctx context.Context, region, bucket, path string, data []byte) (string, error) {
+	var opts []func(*awsconfig.LoadOptions) error
+	if region != "" {
+		opts = append(opts, awsconfig.WithRegion(region))
+	}
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return "", fmt.Errorf("loading AWS config: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	key := "claude-bedrock-cli/videos/" + hex.EncodeToString(sum[:]) + filepath.Ext(path)
+	note("run: uploading %d MiB video to s3://%s/%s", len(data)>>20, bucket, key)
+	_, err = s3.NewFromConfig(cfg).PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(data),
+	})
+	if err != nil {
+		return "", fmt.Errorf("run: uploading video: %w", err)
+	}
+	return fmt.Sprintf("s3://%s/%s", bucket, key), nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce batches the burst of filesystem events an editor emits on
+// save into a single re-run.
+const watchDebounce = 300 * time.Millisecond
+
+// watchFiles runs fn once, then again each time one of the watched files
+// changes, until ctx is cancelled. Errors from fn are printed rather than
+// returned so one bad run does not end the watch.
+func watchFiles(ctx context.Context, paths []string, fn func(context.Context) error) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("watch: %w", err)
+	}
+	defer watcher.Close()
+
+	watched := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return err
+		}
+		watched[abs] = true
+		// Watch the directory rather than the file: most editors replace
+		// the file on save, which silently drops a direct file watch.
+		if err := watcher.Add(filepath.Dir(abs)); err != nil {
+			return fmt.Errorf("watch %s: %w", p, err)
+		}
+	}
+
+	run := func() {
+		if err := fn(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "watch: %v\n", err)
+		}
+	}
+	run()
+
+	debounce := time.NewTimer(watchDebounce)
+	if !debounce.Stop() {
+		<-debounce.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			abs, err := filepath.Abs(event.Name)
+			if err != nil || !watched[abs] {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+				continue
+			}
+			debounce.Reset(watchDebounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Fprintf(os.Stderr, "watch: %v\n", err)
+		case <-debounce.C:
+			run()
+		}
+	}
+}